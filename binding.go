@@ -0,0 +1,165 @@
+package v8js
+
+// #cgo CXXFLAGS: -fno-rtti -fPIC -std=c++17 -DV8_COMPRESS_POINTERS -DV8_31BIT_SMIS_ON_64BIT_ARCH -I${SRCDIR}/deps/include -Wall -DV8_ENABLE_SANDBOX
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/herb-go/v8go"
+
+	_ "github.com/jarlyyn/v8js/deps/include"
+)
+
+// Native features not bound by v8go are implemented in v8js.cc,
+// on the same isolates,contexts and values as v8go.
+//
+// Layouts of unexported fields are pinned to github.com/herb-go/v8go v0.0.0-20251114063147-99ce5e20c7c0 (V8 9.0),
+// and checked when package initialized.
+
+var valuePtrOffset, valueCtxOffset uintptr
+var scriptPtrOffset, scriptIsoOffset uintptr
+
+var nativePointerType = reflect.TypeOf(unsafe.Pointer(nil))
+
+// fieldOffset returns offset of unexported field of v8go struct.
+// Panics if field is missing or its type is not expected,as writing by a wrong offset corrupts memory.
+// Native pointer fields are expected if typ is nil.
+func fieldOffset(v interface{}, name string, typ reflect.Type) uintptr {
+	f, ok := reflect.TypeOf(v).FieldByName(name)
+	if !ok {
+		panic("v8js: unsupported v8go version,field " + name + " not found")
+	}
+	if typ == nil {
+		if f.Type.Kind() != reflect.Ptr || f.Type.Size() != nativePointerType.Size() {
+			panic("v8js: unsupported v8go version,field " + name + " is not native pointer")
+		}
+	} else if f.Type != typ {
+		panic("v8js: unsupported v8go version,field " + name + " is not " + typ.String())
+	}
+	return f.Offset
+}
+
+func init() {
+	valuePtrOffset = fieldOffset(v8go.Value{}, "ptr", nil)
+	valueCtxOffset = fieldOffset(v8go.Value{}, "ctx", reflect.TypeOf((*v8go.Context)(nil)))
+	scriptPtrOffset = fieldOffset(v8go.UnboundScript{}, "ptr", nil)
+	scriptIsoOffset = fieldOffset(v8go.UnboundScript{}, "iso", reflect.TypeOf((*v8go.Isolate)(nil)))
+	fieldOffset(v8go.Isolate{}, "ptr", nil)
+	fieldOffset(v8go.Context{}, "ptr", nil)
+	fieldOffset(v8go.Context{}, "ref", reflect.TypeOf(int(0)))
+	fieldOffset(v8go.CPUProfiler{}, "p", nil)
+	fieldOffset(v8go.CPUProfile{}, "p", nil)
+}
+
+// nativePtr returns the unexported native pointer of v8go isolate,context,value or unbound script.
+func nativePtr(v interface{}) unsafe.Pointer {
+	return reflect.ValueOf(v).Elem().FieldByName("ptr").UnsafePointer()
+}
+
+var contextsLocker sync.Mutex
+var contexts = map[int]*Context{}
+
+// contextRef returns registry ref of v8go context,which is stored in embedder data of v8 context by v8go.
+func contextRef(raw *v8go.Context) int {
+	return int(reflect.ValueOf(raw).Elem().FieldByName("ref").Int())
+}
+
+// register registers context,so native callbacks can find it by v8 context.
+func (c *Context) register() {
+	contextsLocker.Lock()
+	defer contextsLocker.Unlock()
+	contexts[contextRef(c.Raw)] = c
+}
+
+// unregister unregisters raw context of context,and drops native state of raw context.
+func (c *Context) unregister(raw *v8go.Context) {
+	C.V8jsForgetContext(C.V8jsContextPtr(nativePtr(raw)))
+	contextsLocker.Lock()
+	defer contextsLocker.Unlock()
+	delete(contexts, contextRef(raw))
+}
+
+func contextByRef(ref int) *Context {
+	contextsLocker.Lock()
+	defer contextsLocker.Unlock()
+	return contexts[ref]
+}
+
+func (c *Context) nativeContext() C.V8jsContextPtr {
+	return C.V8jsContextPtr(nativePtr(c.Raw))
+}
+
+func (v *JsValue) native() C.V8jsValuePtr {
+	return C.V8jsValuePtr(nativePtr(v.raw))
+}
+
+// newRawValue creates v8go value of native value.
+func newRawValue(ptr C.V8jsValuePtr, ctx *v8go.Context) *v8go.Value {
+	raw := &v8go.Value{}
+	*(*unsafe.Pointer)(unsafe.Add(unsafe.Pointer(raw), valuePtrOffset)) = unsafe.Pointer(ptr)
+	*(**v8go.Context)(unsafe.Add(unsafe.Pointer(raw), valueCtxOffset)) = ctx
+	return raw
+}
+
+// rawNative creates v8go value of native value tracked by context.
+func (c *Context) rawNative(ptr C.V8jsValuePtr) *v8go.Value {
+	return newRawValue(ptr, c.Raw)
+}
+
+// releaseNative releases native value which is not wrapped.
+func releaseNative(ptr C.V8jsValuePtr) {
+	newRawValue(ptr, nil).Release()
+}
+
+// wrapNative wraps native value tracked by context as JsValue.
+// Returns nil if ptr is nil.
+func (c *Context) wrapNative(ptr C.V8jsValuePtr) *JsValue {
+	if ptr == nil {
+		return nil
+	}
+	return c.Wrap(c.rawNative(ptr))
+}
+
+// copy returns new handle of value,which should be released separately.
+func (v *JsValue) copy() *JsValue {
+	result := v.ctx.wrapNative(C.V8jsValueCopy(v.ctx.nativeContext(), v.native()))
+	runtime.KeepAlive(v)
+	return result
+}
+
+// handOver returns new untracked handle of value in context,for v8go which releases it after used.
+func (c *Context) handOver(v *JsValue) *v8go.Value {
+	result := c.rawNative(C.V8jsValueCopy(c.nativeContext(), v.native()))
+	runtime.KeepAlive(v)
+	return result
+}
+
+// newUnboundScript creates v8go unbound script of native unbound script tracked by isolate.
+func newUnboundScript(ptr C.V8jsUnboundScriptPtr, iso *v8go.Isolate) *v8go.UnboundScript {
+	raw := &v8go.UnboundScript{}
+	*(*unsafe.Pointer)(unsafe.Add(unsafe.Pointer(raw), scriptPtrOffset)) = unsafe.Pointer(ptr)
+	*(**v8go.Isolate)(unsafe.Add(unsafe.Pointer(raw), scriptIsoOffset)) = iso
+	return raw
+}
+
+// nativeError converts native error to v8go.JSError,and frees native error.
+// Return nil if no error.
+func nativeError(e C.V8jsError) error {
+	if e.msg == nil {
+		return nil
+	}
+	err := &v8go.JSError{
+		Message:    C.GoString(e.msg),
+		Location:   C.GoString(e.location),
+		StackTrace: C.GoString(e.stack),
+	}
+	C.free(unsafe.Pointer(e.msg))
+	C.free(unsafe.Pointer(e.location))
+	C.free(unsafe.Pointer(e.stack))
+	return err
+}
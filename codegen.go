@@ -0,0 +1,114 @@
+package v8js
+
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import "strings"
+
+// CodeGenerationMaxSourceLength max length of source reported in EvalError when code generation disallowed.
+const CodeGenerationMaxSourceLength = 64
+
+// Kinds of code generation from strings passed to CodeGenerationFilter.
+const (
+	CodeGenerationEval       = "eval"
+	CodeGenerationFunction   = "Function"
+	CodeGenerationSetTimeout = "setTimeout"
+)
+
+// CodeGenerationFilter decides whether code generation from given source is allowed.
+// Kind is one of CodeGenerationEval,CodeGenerationFunction and CodeGenerationSetTimeout.
+type CodeGenerationFilter func(kind string, source string) bool
+
+// codeGenerationBootstrap replaces string timers with checked wrappers,
+// which are not checked by v8 as timers are not part of v8.
+const codeGenerationBootstrap = `(function(check) {
+	const NativeEvalError = EvalError;
+	for (const name of ["setTimeout", "setInterval"]) {
+		const timer = globalThis[name];
+		if (typeof timer !== "function") {
+			continue;
+		}
+		const checked = {
+			[name](handler, ...args) {
+				if (typeof handler === "string") {
+					const denied = check(handler);
+					if (denied !== undefined) {
+						throw new NativeEvalError(denied);
+					}
+				}
+				return timer.call(this, handler, ...args);
+			}
+		}[name];
+		Object.defineProperty(globalThis, name, { value: checked, writable: true, configurable: true });
+	}
+})`
+
+// functionSourcePrefixes prefixes of sources created by function constructors.
+var functionSourcePrefixes = []string{
+	"(function anonymous(",
+	"(async function anonymous(",
+	"(function* anonymous(",
+	"(async function* anonymous(",
+}
+
+// codeGenerationKind returns kind of source checked by v8,
+// sources of function constructors are recognized by format v8 creates them.
+func codeGenerationKind(source string) string {
+	for _, prefix := range functionSourcePrefixes {
+		if strings.HasPrefix(source, prefix) && strings.HasSuffix(source, "\n})") {
+			return CodeGenerationFunction
+		}
+	}
+	return CodeGenerationEval
+}
+
+// codeGenerationDenied returns message of EvalError thrown for blocked source.
+func codeGenerationDenied(source string) string {
+	if runes := []rune(source); len(runes) > CodeGenerationMaxSourceLength {
+		source = string(runes[:CodeGenerationMaxSourceLength]) + "..."
+	}
+	return "Code generation from strings disallowed for this context: " + source
+}
+
+func (c *Context) allowCodeGeneration(kind string, source string) bool {
+	return c.codeGenerationFilter != nil && c.codeGenerationFilter(kind, source)
+}
+
+// DisallowCodeGeneration disallows code generation from strings in context,
+// including eval,new Function and setTimeout or setInterval with string handler.
+// Source allowed by filter will still be executed,all sources are blocked if filter is nil.
+// Blocked calls throw EvalError with source truncated to CodeGenerationMaxSourceLength.
+//
+// Eval and function constructors are checked by v8,filter receives exact source v8 compiles,
+// after arguments converted to string.
+// Timer wrappers are installed into globals at call time,so timers should be installed before calling this method,
+// and globals should be frozen for untrusted scripts.
+func (c *Context) DisallowCodeGeneration(filter CodeGenerationFilter) {
+	c.keepPolicy(func(ctx *Context) {
+		ctx.DisallowCodeGeneration(filter)
+	})
+	c.codeGenerationFilter = filter
+	C.V8jsDisallowCodeGeneration(c.nativeContext())
+	install := c.RunScript(codeGenerationBootstrap, "codegeneration.js")
+	defer install.Release()
+	check := c.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		ctx := info.Context()
+		source := info.GetArg(0).String()
+		if ctx.allowCodeGeneration(CodeGenerationSetTimeout, source) {
+			return nil
+		}
+		return ctx.NewString(codeGenerationDenied(source)).Consume()
+	})
+	install.Call(c.NullValue(), check.Consume()).Release()
+}
+
+//export v8jsCheckCodeGeneration
+func v8jsCheckCodeGeneration(ref C.int, data *C.char, length C.int) *C.char {
+	c := contextByRef(int(ref))
+	source := C.GoStringN(data, length)
+	if c != nil && c.allowCodeGeneration(codeGenerationKind(source), source) {
+		return nil
+	}
+	// freed by native code.
+	return C.CString(codeGenerationDenied(source))
+}
@@ -0,0 +1,76 @@
+package v8js
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDisallowCodeGeneration(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	var sources []string
+	ctx.DisallowCodeGeneration(func(kind string, source string) bool {
+		sources = append(sources, kind+":"+source)
+		return source == "1 + 1" || strings.Contains(source, "'allowed'")
+	})
+	scripts := []string{
+		`eval("globalThis")`,
+		`new Function("a", "return a")`,
+		`Function("return 1")`,
+		`(function(){}).constructor("return 1")`,
+		`Object.getPrototypeOf(async function(){}).constructor("return 1")`,
+		`Object.getPrototypeOf(function*(){}).constructor("yield 1")`,
+		`eval("` + strings.Repeat("a", 100) + `")`,
+	}
+	for _, script := range scripts {
+		result := ctx.RunScript(`try { `+script+`; "allowed" } catch (e) { e instanceof EvalError ? e.message : String(e) }`, "main.js")
+		if !strings.HasPrefix(result.String(), "Code generation from strings disallowed") {
+			t.Fatal(script, result.String())
+		}
+		if len(result.String()) > len("Code generation from strings disallowed for this context: ")+CodeGenerationMaxSourceLength+3 {
+			t.Fatal(result.String())
+		}
+		result.Release()
+	}
+	if sources[1] != "Function:(function anonymous(a\n) {\nreturn a\n})" {
+		t.Fatal(sources[1])
+	}
+	result := ctx.RunScript(`[eval("1 + 1"), eval(3), (function(){}) instanceof Function, Function.name]`, "main.js")
+	defer result.Release()
+	if result.GetIdx(0).Int32() != 2 || result.GetIdx(1).Int32() != 3 || !result.GetIdx(2).Boolean() || result.GetIdx(3).String() != "Function" {
+		t.Fatal(result.String())
+	}
+	fn := ctx.RunScript(`new Function("return 'allowed'")`, "main.js")
+	defer fn.Release()
+	if fn.Call(ctx.NullValue()).String() != "allowed" {
+		t.Fatal()
+	}
+}
+
+func TestDisallowCodeGenerationCoercion(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	var sources []string
+	ctx.DisallowCodeGeneration(func(kind string, source string) bool {
+		sources = append(sources, kind+":"+source)
+		return strings.Contains(source, "1 + 1")
+	})
+	result := ctx.RunScript(`var count = 0;
+var body = { toString() { return count++ ? "globalThis.hacked = true" : "return 1 + 1"; } };
+[new Function(body)(), typeof hacked, count, eval(body) === body]`, "main.js")
+	defer result.Release()
+	if result.GetIdx(0).Int32() != 2 || result.GetIdx(1).String() != "undefined" {
+		t.Fatal(result.String())
+	}
+	if result.GetIdx(2).Int32() != 1 || !result.GetIdx(3).Boolean() {
+		t.Fatal(result.String())
+	}
+	if len(sources) != 1 || sources[0] != "Function:(function anonymous(\n) {\nreturn 1 + 1\n})" {
+		t.Fatal(sources)
+	}
+	result = ctx.RunScript(`(function() { var local = 2; return eval("local * 1 + 1"); })()`, "main.js")
+	defer result.Release()
+	if result.Int32() != 3 {
+		t.Fatal(result.String())
+	}
+}
@@ -0,0 +1,132 @@
+package console
+
+import (
+	"strconv"
+	"strings"
+
+	v8js "github.com/jarlyyn/v8js"
+)
+
+// Level console output level.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// Entry console output entry.
+type Entry struct {
+	Level Level
+	// Method console method name,like "log" or "timeEnd".
+	Method string
+	// Message formatted message.
+	Message string
+	// Tag tag of console,like plugin name.
+	Tag string
+	// Frame script location where console method called,nil if unknown.
+	Frame *v8js.StackFrame
+	// Stack captured stack trace,only first frame captured except for console.trace.
+	Stack string
+}
+
+func (e *Entry) String() string {
+	var sb strings.Builder
+	if e.Tag != "" {
+		sb.WriteString("[" + e.Tag + "] ")
+	}
+	sb.WriteString(strings.ToUpper(e.Level.String()))
+	if e.Frame != nil {
+		sb.WriteString(" " + e.Frame.Script)
+		if e.Frame.Line > 0 {
+			sb.WriteString(":" + strconv.Itoa(e.Frame.Line) + ":" + strconv.Itoa(e.Frame.Column))
+		}
+	}
+	sb.WriteString(" " + e.Message)
+	if e.Method == "trace" {
+		for _, f := range v8js.ParseStackTrace(e.Stack) {
+			sb.WriteString("\n    at " + f.String())
+		}
+	}
+	return sb.String()
+}
+
+// Logger logger which receives console output.
+type Logger interface {
+	Log(entry *Entry)
+}
+
+// LoggerFunc func which implements Logger.
+type LoggerFunc func(entry *Entry)
+
+func (f LoggerFunc) Log(entry *Entry) {
+	f(entry)
+}
+
+// NewPrinterLogger creates logger which prints entries as string with given printer.
+func NewPrinterLogger(printer func(info string)) Logger {
+	return LoggerFunc(func(entry *Entry) {
+		printer(entry.String())
+	})
+}
+
+// Console console subsystem routed to Logger.
+type Console struct {
+	Tag    string
+	Logger Logger
+}
+
+func (c *Console) sink(call *v8js.FunctionCallbackInfo) *v8js.Consumed {
+	stack := call.GetArg(3).String()
+	entry := &Entry{
+		Level:   Level(call.GetArg(0).Int32()),
+		Method:  call.GetArg(1).String(),
+		Message: call.GetArg(2).String(),
+		Tag:     c.Tag,
+		Stack:   stack,
+	}
+	frames := v8js.ParseStackTrace(stack)
+	if len(frames) > 0 {
+		entry.Frame = frames[0]
+	}
+	c.Logger.Log(entry)
+	return nil
+}
+
+// Convert creates console object in given context.
+func (c *Console) Convert(r *v8js.Context) *v8js.JsValue {
+	factory := r.RunScript(consoleScript, "console.js")
+	defer factory.Release()
+	return factory.Call(r.NullValue(), r.NewFunction(c.sink).Consume())
+}
+
+// Install sets console object as global "console" in given context.
+func (c *Console) Install(r *v8js.Context) {
+	global := r.Global()
+	defer global.Release()
+	global.Set("console", c.Convert(r).Consume())
+}
+
+// Create creates console with given tag and logger.
+func Create(tag string, logger Logger) *Console {
+	return &Console{
+		Tag:    tag,
+		Logger: logger,
+	}
+}
@@ -0,0 +1,150 @@
+package console
+
+import (
+	"strings"
+	"testing"
+
+	v8js "github.com/jarlyyn/v8js"
+)
+
+func TestConsole(t *testing.T) {
+	ctx := v8js.NewContext()
+	defer ctx.Close()
+	var entries []*Entry
+	Create("test", LoggerFunc(func(entry *Entry) {
+		entries = append(entries, entry)
+	})).Install(ctx)
+	ctx.RunScript(`
+console.log("hello %s %d%%", "world", 42, {a: [1, {b: 2}], s: "str"});
+console.warn("warn");
+console.error(new Error("failed").message);
+console.debug(1n, null, undefined);
+console.assert(true, "ok");
+console.assert(false, "value is %j", {x: 1});
+console.count();
+console.count();
+console.group("group");
+console.info("inner");
+console.groupEnd();
+console.table([{a: 1, b: "x"}, {a: 2}]);
+function traced() {
+	console.trace("here");
+}
+traced();
+console.time("t");
+console.timeEnd("t");
+console.timeEnd("missing");
+`, "main.js")
+	expected := []struct {
+		level   Level
+		method  string
+		message string
+	}{
+		{LevelInfo, "log", `hello world 42% { a: [ 1, [Object] ], s: "str" }`},
+		{LevelWarn, "warn", "warn"},
+		{LevelError, "error", "failed"},
+		{LevelDebug, "debug", "1n null undefined"},
+		{LevelError, "assert", `Assertion failed: value is {"x":1}`},
+		{LevelInfo, "count", "default: 1"},
+		{LevelInfo, "count", "default: 2"},
+		{LevelInfo, "group", "group"},
+		{LevelInfo, "info", "  inner"},
+		{LevelInfo, "table", "┌─────────┬───┬─────┐\n│ (index) │ a │  b  │\n├─────────┼───┼─────┤\n│    0    │ 1 │ \"x\" │\n│    1    │ 2 │     │\n└─────────┴───┴─────┘"},
+		{LevelInfo, "trace", "Trace: here"},
+		{LevelInfo, "timeEnd", ""},
+		{LevelInfo, "timeEnd", "No such label 'missing' for console.timeEnd()"},
+	}
+	if len(entries) != len(expected) {
+		for _, e := range entries {
+			t.Log(e.String())
+		}
+		t.Fatal(len(entries))
+	}
+	for i, e := range expected {
+		entry := entries[i]
+		if entry.Level != e.level || entry.Method != e.method || entry.Tag != "test" {
+			t.Fatal(i, entry)
+		}
+		if e.message != "" && entry.Message != e.message {
+			t.Fatal(i, entry.Message)
+		}
+	}
+	if f := entries[0].Frame; f == nil || f.Script != "main.js" || f.Line != 2 || f.Column != 9 {
+		t.Fatal(f)
+	}
+	trace := entries[10]
+	if len(v8js.ParseStackTrace(trace.Stack)) != 2 || trace.Frame.Function != "traced" {
+		t.Fatal(trace.Stack)
+	}
+	if !strings.HasPrefix(entries[11].Message, "t: ") || !strings.HasSuffix(entries[11].Message, "ms") {
+		t.Fatal(entries[11].Message)
+	}
+	if entries[1].String() != "[test] WARN main.js:3:9 warn" {
+		t.Fatal(entries[1].String())
+	}
+}
+
+func TestConsoleInspector(t *testing.T) {
+	ctx := v8js.NewContext()
+	defer ctx.Close()
+	var messages []string
+	i := ctx.NewInspector("test")
+	s := i.Connect(func(message string) {
+		messages = append(messages, message)
+	})
+	s.Dispatch(`{"id":1,"method":"Runtime.enable"}`)
+	i.DispatchMessages()
+	var entries []*Entry
+	Create("test", LoggerFunc(func(entry *Entry) {
+		entries = append(entries, entry)
+	})).Install(ctx)
+	Create("test", LoggerFunc(func(entry *Entry) {
+		entries = append(entries, entry)
+	})).Install(ctx)
+	messages = nil
+	ctx.RunScript(`console.warn("to inspector")`, "main.js").Release()
+	called := 0
+	for _, message := range messages {
+		if strings.Contains(message, `"method":"Runtime.consoleAPICalled"`) && strings.Contains(message, `"type":"warning"`) && strings.Contains(message, "to inspector") {
+			called++
+		}
+	}
+	if called != 1 || len(entries) != 1 {
+		t.Fatal(messages, entries)
+	}
+}
+
+func TestConsoleSandbox(t *testing.T) {
+	ctx := v8js.NewContext()
+	defer ctx.Close()
+	var entries []*Entry
+	Create("test", LoggerFunc(func(entry *Entry) {
+		entries = append(entries, entry)
+	})).Install(ctx)
+	ctx.ApplySandbox(&v8js.SandboxOptions{Globals: []string{"console"}})
+	ctx.RunScript(`
+let error;
+try { null.x; } catch (e) { error = e; }
+console.log("%j", {a: 1}, [1, {b: "c"}], error.name);
+console.error(error);
+console.table([{a: 1}]);
+console.trace("here");
+`, "main.js")
+	if len(entries) != 4 {
+		t.Fatal(len(entries))
+	}
+	if entries[0].Message != `{"a":1} [ 1, { b: "c" } ] TypeError` {
+		t.Fatal(entries[0].Message)
+	}
+	if !strings.HasPrefix(entries[1].Message, "TypeError: Cannot read property 'x' of null\n    at main.js:3") {
+		t.Fatal(entries[1].Message)
+	}
+	if !strings.Contains(entries[2].Message, "│ a │") || entries[3].Frame == nil || entries[3].Frame.Script != "main.js" {
+		t.Fatal(entries[2].Message, entries[3].Frame)
+	}
+	result := ctx.RunScript(`typeof JSON + typeof Map + typeof Error + typeof Math`, "main.js")
+	defer result.Release()
+	if result.String() != "undefinedundefinedundefinedundefined" {
+		t.Fatal(result.String())
+	}
+}
@@ -0,0 +1,250 @@
+package console
+
+// consoleScript creates console object.
+// All output is sent to sink(level,method,message,stack),stack is the caller location captured by Error.captureStackTrace.
+// Calls are forwarded to builtin console of v8 too,which reports them to inspector sessions.
+// Builtins are captured when installed,so console keeps working after globals removed by sandbox.
+const consoleScript = `(function(sink) {
+	const { Object, Array, Error, String, Number, JSON, Map, Set, Date, RegExp, ArrayBuffer, Math, Reflect, parseInt, parseFloat, isNaN } = globalThis;
+	const builtinKey = Symbol.for("v8js.console.builtin");
+	const current = globalThis.console;
+	const builtin = current && current[builtinKey] ? current[builtinKey] : current;
+	const apply = Reflect.apply;
+	const maxDepth = 2;
+	const inspectString = (v) => JSON.stringify(v);
+	const inspectKey = (k) => /^[A-Za-z_$][A-Za-z0-9_$]*$/.test(k) ? k : inspectString(k);
+	const inspect = (v, depth, seen) => {
+		switch (typeof v) {
+		case "string":
+			return depth > 0 ? inspectString(v) : v;
+		case "bigint":
+			return v + "n";
+		case "symbol":
+			return v.toString();
+		case "function":
+			return v.name ? "[Function: " + v.name + "]" : "[Function (anonymous)]";
+		case "object":
+			break;
+		default:
+			return String(v);
+		}
+		if (v === null) {
+			return "null";
+		}
+		if (seen.indexOf(v) >= 0) {
+			return "[Circular]";
+		}
+		if (v instanceof Error) {
+			return v.stack ? String(v.stack) : String(v);
+		}
+		if (v instanceof Date) {
+			return isNaN(v.getTime()) ? "Invalid Date" : v.toISOString();
+		}
+		if (v instanceof RegExp) {
+			return String(v);
+		}
+		const inner = (item) => inspect(item, depth + 1, seen.concat([v]));
+		const list = (open, items, close) => items.length ? open + " " + items.join(", ") + " " + close : open + close;
+		if (Array.isArray(v)) {
+			if (depth >= maxDepth) {
+				return "[Array]";
+			}
+			return list("[", v.map(inner), "]");
+		}
+		if (v instanceof Map) {
+			if (depth >= maxDepth) {
+				return "[Map]";
+			}
+			return list("Map(" + v.size + ") {", Array.from(v.entries()).map(([k, val]) => inner(k) + " => " + inner(val)), "}");
+		}
+		if (v instanceof Set) {
+			if (depth >= maxDepth) {
+				return "[Set]";
+			}
+			return list("Set(" + v.size + ") {", Array.from(v.values()).map(inner), "}");
+		}
+		if (v instanceof ArrayBuffer) {
+			return "ArrayBuffer { byteLength: " + v.byteLength + " }";
+		}
+		if (depth >= maxDepth) {
+			return "[Object]";
+		}
+		return list("{", Object.keys(v).map((k) => inspectKey(k) + ": " + inner(v[k])), "}");
+	};
+	const format = (args) => {
+		const parts = [];
+		let i = 0;
+		if (typeof args[0] === "string") {
+			i = 1;
+			parts.push(args[0].replace(/%([sdifjoOc%])/g, (match, flag) => {
+				if (flag === "%") {
+					return "%";
+				}
+				if (i >= args.length) {
+					return match;
+				}
+				const arg = args[i++];
+				switch (flag) {
+				case "s":
+					return typeof arg === "string" ? arg : inspect(arg, 1, []);
+				case "d":
+					return typeof arg === "bigint" ? arg + "n" : String(Number(arg));
+				case "i":
+					return typeof arg === "bigint" ? arg + "n" : String(parseInt(arg));
+				case "f":
+					return String(parseFloat(arg));
+				case "j":
+					try {
+						return JSON.stringify(arg);
+					} catch (e) {
+						return "[Circular]";
+					}
+				case "c":
+					return "";
+				}
+				return inspect(arg, 0, []);
+			}));
+		}
+		for (; i < args.length; i++) {
+			parts.push(inspect(args[i], 0, []));
+		}
+		return parts.join(" ");
+	};
+	const table = (data, columns) => {
+		if (data === null || typeof data !== "object") {
+			return format([data]);
+		}
+		const valuesKey = "Values";
+		const header = ["(index)"];
+		const rows = [];
+		const addColumn = (name) => {
+			if (header.indexOf(name) < 0) {
+				header.push(name);
+			}
+		};
+		const entries = data instanceof Map ? Array.from(data.entries()) : Object.keys(data).map((k) => [k, data[k]]);
+		entries.forEach(([index, row]) => {
+			const cells = { "(index)": inspect(index, 0, []) };
+			if (row !== null && typeof row === "object") {
+				Object.keys(row).forEach((k) => {
+					if (!columns || columns.indexOf(k) >= 0) {
+						addColumn(k);
+						cells[k] = inspect(row[k], 1, []);
+					}
+				});
+			} else {
+				cells[valuesKey] = inspect(row, 1, []);
+			}
+			rows.push(cells);
+		});
+		if (rows.some((cells) => valuesKey in cells)) {
+			addColumn(valuesKey);
+		}
+		const widths = header.map((h) => Math.max(h.length, ...rows.map((cells) => (cells[h] || "").length)) + 2);
+		const pad = (s, w) => {
+			const left = Math.floor((w - s.length) / 2);
+			return " ".repeat(left) + s + " ".repeat(w - s.length - left);
+		};
+		const line = (l, m, r) => l + widths.map((w) => "─".repeat(w)).join(m) + r;
+		const out = [line("┌", "┬", "┐")];
+		out.push("│" + header.map((h, i) => pad(h, widths[i])).join("│") + "│");
+		out.push(line("├", "┼", "┤"));
+		rows.forEach((cells) => out.push("│" + header.map((h, i) => pad(cells[h] || "", widths[i])).join("│") + "│"));
+		out.push(line("└", "┴", "┘"));
+		return out.join("\n");
+	};
+	let indent = "";
+	const timers = new Map();
+	const counts = new Map();
+	const write = (level, method, message, caller, stackLimit) => {
+		const holder = {};
+		const limit = Error.stackTraceLimit;
+		Error.stackTraceLimit = stackLimit;
+		Error.captureStackTrace(holder, caller);
+		Error.stackTraceLimit = limit;
+		const text = indent ? message.split("\n").map((l) => indent + l).join("\n") : message;
+		sink(level, method, text, String(holder.stack));
+	};
+	const console = {};
+	Object.defineProperty(console, builtinKey, { value: builtin });
+	const def = (name, level, impl, stackLimit) => {
+		const fn = {
+			[name](...args) {
+				if (builtin && typeof builtin[name] === "function") {
+					apply(builtin[name], builtin, args);
+				}
+				const message = impl(args);
+				if (message !== undefined) {
+					write(level, name, message, fn, stackLimit || 1);
+				}
+			}
+		}[name];
+		Object.defineProperty(console, name, { value: fn, writable: true, enumerable: true, configurable: true });
+	};
+	const label = (args) => args.length && args[0] !== undefined ? String(args[0]) : "default";
+	def("log", 1, format);
+	def("info", 1, format);
+	def("debug", 0, format);
+	def("warn", 2, format);
+	def("error", 3, format);
+	def("dir", 1, (args) => inspect(args[0], 0, []));
+	def("trace", 1, (args) => "Trace" + (args.length ? ": " + format(args) : ""), 10);
+	def("table", 1, (args) => table(args[0], args[1]));
+	def("assert", 3, (args) => {
+		if (args[0]) {
+			return undefined;
+		}
+		const rest = args.slice(1);
+		return "Assertion failed" + (rest.length ? ": " + format(rest) : "");
+	});
+	def("count", 1, (args) => {
+		const l = label(args);
+		const n = (counts.get(l) || 0) + 1;
+		counts.set(l, n);
+		return l + ": " + n;
+	});
+	def("countReset", 2, (args) => {
+		const l = label(args);
+		if (!counts.has(l)) {
+			return "Count for '" + l + "' does not exist";
+		}
+		counts.delete(l);
+		return undefined;
+	});
+	def("time", 2, (args) => {
+		const l = label(args);
+		if (timers.has(l)) {
+			return "Label '" + l + "' already exists for console.time()";
+		}
+		timers.set(l, Date.now());
+		return undefined;
+	});
+	const elapsed = (args, remove) => {
+		const l = label(args);
+		if (!timers.has(l)) {
+			return "No such label '" + l + "' for console." + (remove ? "timeEnd()" : "timeLog()");
+		}
+		const ms = Date.now() - timers.get(l);
+		if (remove) {
+			timers.delete(l);
+		}
+		const extra = args.slice(1);
+		return l + ": " + ms + "ms" + (extra.length ? " " + format(extra) : "");
+	};
+	def("timeLog", 1, (args) => elapsed(args, false));
+	def("timeEnd", 1, (args) => elapsed(args, true));
+	def("group", 1, (args) => {
+		const message = args.length ? format(args) : undefined;
+		if (message !== undefined) {
+			write(1, "group", message, console.group, 1);
+		}
+		indent += "  ";
+		return undefined;
+	});
+	console.groupCollapsed = console.group;
+	def("groupEnd", 1, () => {
+		indent = indent.slice(2);
+		return undefined;
+	});
+	return console;
+})`
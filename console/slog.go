@@ -0,0 +1,50 @@
+//go:build go1.21
+
+package console
+
+import (
+	"context"
+	"log/slog"
+)
+
+var slogLevels = map[Level]slog.Level{
+	LevelDebug: slog.LevelDebug,
+	LevelInfo:  slog.LevelInfo,
+	LevelWarn:  slog.LevelWarn,
+	LevelError: slog.LevelError,
+}
+
+// SlogLogger logger which writes console output to slog.Logger.
+// Tag and script location are written as attrs.
+type SlogLogger struct {
+	Logger *slog.Logger
+}
+
+func (l *SlogLogger) Log(entry *Entry) {
+	attrs := []slog.Attr{
+		slog.String("method", entry.Method),
+	}
+	if entry.Tag != "" {
+		attrs = append(attrs, slog.String("plugin", entry.Tag))
+	}
+	if entry.Frame != nil {
+		attrs = append(attrs,
+			slog.String("script", entry.Frame.Script),
+			slog.Int("line", entry.Frame.Line),
+			slog.Int("column", entry.Frame.Column),
+		)
+	}
+	if entry.Method == "trace" {
+		attrs = append(attrs, slog.String("stack", entry.Stack))
+	}
+	l.Logger.LogAttrs(context.Background(), slogLevels[entry.Level], entry.Message, attrs...)
+}
+
+// NewSlogLogger creates console logger with given slog.Logger.
+// slog.Default() will be used if logger is nil.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{Logger: logger}
+}
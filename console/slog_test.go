@@ -0,0 +1,25 @@
+//go:build go1.21
+
+package console
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	v8js "github.com/jarlyyn/v8js"
+)
+
+func TestSlogLogger(t *testing.T) {
+	ctx := v8js.NewContext()
+	defer ctx.Close()
+	buf := bytes.NewBuffer(nil)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	Create("plugin", NewSlogLogger(logger)).Install(ctx)
+	ctx.RunScript(`console.warn("msg")`, "main.js")
+	output := buf.String()
+	if !strings.Contains(output, "level=WARN msg=msg method=warn plugin=plugin script=main.js line=1 column=9") {
+		t.Fatal(output)
+	}
+}
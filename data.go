@@ -0,0 +1,50 @@
+package v8js
+
+import "errors"
+
+var ErrDataNotFound = errors.New("v8js: context data not found")
+
+// SetData attaches Go value to context with given key,
+// so Go callbacks can look it up by FunctionCallbackInfo.Context.
+// Key must be comparable,unexported key types are recommended to avoid collisions between packages.
+// Data is dropped when context closed.
+func (c *Context) SetData(key interface{}, value interface{}) {
+	c.dataLocker.Lock()
+	defer c.dataLocker.Unlock()
+	if c.data == nil {
+		c.data = map[interface{}]interface{}{}
+	}
+	c.data[key] = value
+}
+
+// GetData returns Go value attached to context with given key,
+// or nil if not found.
+func (c *Context) GetData(key interface{}) interface{} {
+	c.dataLocker.RLock()
+	defer c.dataLocker.RUnlock()
+	return c.data[key]
+}
+
+// DeleteData removes Go value attached to context with given key.
+func (c *Context) DeleteData(key interface{}) {
+	c.dataLocker.Lock()
+	defer c.dataLocker.Unlock()
+	delete(c.data, key)
+}
+
+// GetDataAs returns Go value attached to context with given key as type T.
+// Returns false if not found or value is not of type T.
+func GetDataAs[T any](c *Context, key interface{}) (T, bool) {
+	v, ok := c.GetData(key).(T)
+	return v, ok
+}
+
+// MustGetDataAs returns Go value attached to context with given key as type T.
+// Panics if not found or value is not of type T.
+func MustGetDataAs[T any](c *Context, key interface{}) T {
+	v, ok := GetDataAs[T](c, key)
+	if !ok {
+		panic(ErrDataNotFound)
+	}
+	return v
+}
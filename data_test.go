@@ -0,0 +1,37 @@
+package v8js
+
+import "testing"
+
+type testDataKey struct{}
+
+func TestData(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	if ctx.GetData(testDataKey{}) != nil {
+		t.Fatal()
+	}
+	ctx.SetData(testDataKey{}, "owner")
+	ctx.Global().Set("owner", ctx.NewFunction(func(call *FunctionCallbackInfo) *Consumed {
+		return call.Context().NewString(MustGetDataAs[string](call.Context(), testDataKey{})).Consume()
+	}).Consume())
+	result := ctx.RunScript(`owner()`, "main.js")
+	defer result.Release()
+	if result.String() != "owner" {
+		t.Fatal(result.String())
+	}
+	if _, ok := GetDataAs[int](ctx, testDataKey{}); ok {
+		t.Fatal()
+	}
+	ctx.DeleteData(testDataKey{})
+	if _, ok := GetDataAs[string](ctx, testDataKey{}); ok {
+		t.Fatal()
+	}
+	func() {
+		defer func() {
+			if recover() != ErrDataNotFound {
+				t.Fatal()
+			}
+		}()
+		MustGetDataAs[string](ctx, testDataKey{})
+	}()
+}
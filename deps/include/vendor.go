@@ -0,0 +1,6 @@
+// Package include is required to provide support for vendoring modules
+// DO NOT REMOVE
+//
+// Headers are V8 9.0 headers of github.com/herb-go/v8go v0.0.0-20251114063147-99ce5e20c7c0,
+// v8js.cc asserts the version when compiled.
+package include
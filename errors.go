@@ -0,0 +1,170 @@
+package v8js
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/herb-go/v8go"
+)
+
+// StackFrame frame of javascript stack trace.
+type StackFrame struct {
+	Function string
+	Script   string
+	Line     int
+	Column   int
+}
+
+func (f *StackFrame) String() string {
+	location := f.Script
+	if f.Line > 0 {
+		location = location + ":" + strconv.Itoa(f.Line)
+		if f.Column > 0 {
+			location = location + ":" + strconv.Itoa(f.Column)
+		}
+	}
+	if f.Function == "" {
+		return location
+	}
+	return f.Function + " (" + location + ")"
+}
+
+func parseStackLocation(frame *StackFrame, location string) {
+	frame.Script = location
+	col := strings.LastIndex(location, ":")
+	if col < 0 {
+		return
+	}
+	column, err := strconv.Atoi(location[col+1:])
+	if err != nil {
+		return
+	}
+	line := strings.LastIndex(location[:col], ":")
+	if line < 0 {
+		return
+	}
+	lineno, err := strconv.Atoi(location[line+1 : col])
+	if err != nil {
+		return
+	}
+	frame.Script = location[:line]
+	frame.Line = lineno
+	frame.Column = column
+}
+
+// ParseStackFrame parses a single line of v8 stack trace like "    at fn (main.js:1:2)".
+// Return nil if line is not a stack frame.
+func ParseStackFrame(line string) *StackFrame {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "at ") {
+		return nil
+	}
+	line = strings.TrimPrefix(line, "at ")
+	frame := &StackFrame{}
+	if strings.HasSuffix(line, ")") {
+		if start := strings.LastIndex(line, " ("); start >= 0 {
+			frame.Function = line[:start]
+			parseStackLocation(frame, line[start+2:len(line)-1])
+			return frame
+		}
+	}
+	parseStackLocation(frame, line)
+	return frame
+}
+
+// ParseStackTrace parses frames from v8 stack trace.
+func ParseStackTrace(stack string) []*StackFrame {
+	var result []*StackFrame
+	for _, line := range strings.Split(stack, "\n") {
+		frame := ParseStackFrame(line)
+		if frame != nil {
+			result = append(result, frame)
+		}
+	}
+	return result
+}
+
+// JSError error thrown by javascript.
+type JSError struct {
+	Message    string
+	Location   string
+	StackTrace string
+	Frames     []*StackFrame
+	// overflow is set if error thrown while unwinding from reentrancy limit,or thrown by v8 when stack size exceeded.
+	overflow bool
+}
+
+func (e *JSError) Error() string {
+	return e.Message
+}
+
+// NewJSError creates new JSError with given message,location and stack trace.
+func NewJSError(message string, location string, stack string) *JSError {
+	return &JSError{
+		Message:    message,
+		Location:   location,
+		StackTrace: stack,
+		Frames:     ParseStackTrace(stack),
+	}
+}
+
+// JSErrorFromValue creates JSError from thrown javascript value.
+func JSErrorFromValue(v *JsValue) *JSError {
+	if v.IsNativeError() {
+		stack := v.Get("stack")
+		defer stack.Release()
+		e := NewJSError(v.String(), "", "")
+		if !stack.IsNullOrUndefined() {
+			e.StackTrace = stack.String()
+			e.Frames = ParseStackTrace(e.StackTrace)
+		}
+		if len(e.Frames) > 0 {
+			f := e.Frames[0]
+			e.Location = (&StackFrame{Script: f.Script, Line: f.Line, Column: f.Column}).String()
+		}
+		return e
+	}
+	return NewJSError(v.String(), "", "")
+}
+
+// UnhandledRejectionError error reported when a promise rejected without handler.
+type UnhandledRejectionError struct {
+	Reason *JSError
+}
+
+func (e *UnhandledRejectionError) Error() string {
+	return "unhandled promise rejection: " + e.Reason.Error()
+}
+
+// convertError maps location of error returned by v8go through source maps.
+// Error type is kept,so callers can still recover *v8go.JSError,
+// except errors thrown while unwinding from reentrancy limit,which are converted to *JSError reporting ErrStackOverflow.
+func (c *Context) convertError(err error) error {
+	if e, ok := err.(*v8go.JSError); ok {
+		mapped := &v8go.JSError{Message: e.Message, Location: e.Location, StackTrace: e.StackTrace}
+		if location := c.mapLocation(e.Location); location != "" {
+			mapped.Location = location
+		}
+		if c.isolate.overflowed.Load() || e.Message == stackOverflowMessage {
+			overflow := ToJSError(mapped)
+			if c.isolate.overflowed.Load() || overflow.recursive() {
+				overflow.overflow = true
+				return overflow
+			}
+		}
+		return mapped
+	}
+	return err
+}
+
+// ToJSError converts error panicked by javascript calls to JSError with parsed stack frames.
+// Return nil if err is not a javascript error.
+func ToJSError(err error) *JSError {
+	switch e := err.(type) {
+	case *JSError:
+		return e
+	case *v8go.JSError:
+		return NewJSError(e.Message, e.Location, e.StackTrace)
+	}
+	return nil
+}
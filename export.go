@@ -0,0 +1,261 @@
+package v8js
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultExportMaxDepth is the max depth used by JsValue.Export.
+var DefaultExportMaxDepth = 64
+
+var ErrExportDepthExceeded = errors.New("v8js: export max depth exceeded")
+var ErrExportCyclicValue = errors.New("v8js: export cyclic value")
+
+var exportHelper = registerHelper("export.js", `((Object, Array, String) => ({
+	keys: (o) => Object.keys(o),
+	entries: (m) => Array.from(m.entries()),
+	values: (s) => Array.from(s.values()),
+	view: (v) => v.buffer.slice(v.byteOffset, v.byteOffset + v.byteLength),
+	time: (d) => d.getTime(),
+	error: (e) => [String(e.name), String(e.message), String(e.stack)]
+}))(Object, Array, String)`)
+
+// ExportOptions options used by JsValue.ExportWithOptions.
+type ExportOptions struct {
+	// MaxDepth max nested depth of arrays,objects,maps and sets.
+	// Zero or negative value means no limit.
+	MaxDepth int
+	// AllowCycles exports cyclic references as nil instead of returning ErrExportCyclicValue.
+	AllowCycles bool
+}
+
+func NewExportOptions() *ExportOptions {
+	return &ExportOptions{
+		MaxDepth: DefaultExportMaxDepth,
+	}
+}
+
+// MapEntry key-value pair exported from js Map.
+type MapEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// ExportedFunction callable wrapper of js function exported by JsValue.Export.
+// You should call Release() when you finish using it.
+type ExportedFunction struct {
+	Value *JsValue
+}
+
+// Call calls wrapped function with undefined receiver.
+func (f *ExportedFunction) Call(args ...*Consumed) *JsValue {
+	return f.Value.Call(f.Value.ctx.NullValue(), args...)
+}
+
+func (f *ExportedFunction) Release() {
+	f.Value.Release()
+}
+
+// ExportedError js native error exported by JsValue.Export.
+type ExportedError struct {
+	Name    string
+	Message string
+	Stack   string
+}
+
+func (e *ExportedError) Error() string {
+	return e.Name + ": " + e.Message
+}
+
+type exporter struct {
+	ctx       *Context
+	opt       *ExportOptions
+	helper    *JsValue
+	ancestors []*JsValue
+	functions []*ExportedFunction
+}
+
+func (e *exporter) call(method string, v *JsValue) *JsValue {
+	return e.helper.MethodCall(method, v.ConsumeReuseble().Consume())
+}
+
+func (e *exporter) enter(v *JsValue) (bool, error) {
+	for _, a := range e.ancestors {
+		if a.SameValue(v) {
+			if e.opt.AllowCycles {
+				return false, nil
+			}
+			return false, ErrExportCyclicValue
+		}
+	}
+	if e.opt.MaxDepth > 0 && len(e.ancestors) >= e.opt.MaxDepth {
+		return false, ErrExportDepthExceeded
+	}
+	e.ancestors = append(e.ancestors, v)
+	return true, nil
+}
+func (e *exporter) leave() {
+	e.ancestors = e.ancestors[:len(e.ancestors)-1]
+}
+
+func (e *exporter) exportList(items *JsValue) ([]interface{}, error) {
+	length := items.Get("length")
+	ln := int(length.Integer())
+	length.Release()
+	result := make([]interface{}, ln)
+	for i := 0; i < ln; i++ {
+		data, err := e.exportChild(items.GetIdx(uint32(i)))
+		if err != nil {
+			return nil, err
+		}
+		result[i] = data
+	}
+	return result, nil
+}
+
+// exportChild exports value and releases it unless it is kept by an ExportedFunction.
+func (e *exporter) exportChild(v *JsValue) (interface{}, error) {
+	if v.IsFunction() {
+		return e.function(v), nil
+	}
+	defer v.Release()
+	return e.export(v)
+}
+
+// function wraps function value,which will be released if export failed.
+func (e *exporter) function(v *JsValue) *ExportedFunction {
+	f := &ExportedFunction{Value: v}
+	e.functions = append(e.functions, f)
+	return f
+}
+
+// releaseFunctions releases wrapped functions when export failed.
+func (e *exporter) releaseFunctions() {
+	for _, f := range e.functions {
+		f.Release()
+	}
+	e.functions = nil
+}
+
+func (e *exporter) export(v *JsValue) (interface{}, error) {
+	switch {
+	case v.IsFunction():
+		// exported function owns its handle,value of caller is kept untouched.
+		return e.function(v.copy()), nil
+	case v.IsNullOrUndefined():
+		return nil, nil
+	case v.IsBoolean():
+		return v.Boolean(), nil
+	case v.IsNumber():
+		return v.Number(), nil
+	case v.IsBigInt():
+		return v.BigInt(), nil
+	case v.export().IsString():
+		return v.String(), nil
+	case v.export().IsSymbol():
+		return nil, fmt.Errorf("v8js: can not export symbol value %s", v.export().DetailString())
+	case v.IsArrayBuffer():
+		return v.ArrayBufferContent(), nil
+	case v.export().IsArrayBufferView():
+		buf := e.call("view", v)
+		defer buf.Release()
+		return buf.ArrayBufferContent(), nil
+	case v.IsDate():
+		ms := e.call("time", v)
+		defer ms.Release()
+		return time.UnixMilli(ms.Integer()), nil
+	case v.IsRegExp():
+		return v.String(), nil
+	case v.IsNativeError():
+		info := e.call("error", v)
+		defer info.Release()
+		s := info.StringArrry()
+		return &ExportedError{Name: s[0], Message: s[1], Stack: s[2]}, nil
+	}
+	ok, err := e.enter(v)
+	if !ok {
+		return nil, err
+	}
+	defer e.leave()
+	switch {
+	case v.IsArray():
+		return e.exportList(v)
+	case v.IsSet():
+		values := e.call("values", v)
+		defer values.Release()
+		return e.exportList(values)
+	case v.IsMap():
+		entries := e.call("entries", v)
+		defer entries.Release()
+		pairs := entries.Array()
+		defer func() {
+			for _, pair := range pairs {
+				pair.Release()
+			}
+		}()
+		result := make([]MapEntry, len(pairs))
+		for i, pair := range pairs {
+			key, err := e.exportChild(pair.GetIdx(0))
+			if err != nil {
+				return nil, err
+			}
+			value, err := e.exportChild(pair.GetIdx(1))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = MapEntry{Key: key, Value: value}
+		}
+		return result, nil
+	}
+	keys := e.call("keys", v)
+	names := keys.StringArrry()
+	keys.Release()
+	result := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		data, err := e.exportChild(v.Get(name))
+		if err != nil {
+			return nil, err
+		}
+		result[name] = data
+	}
+	return result, nil
+}
+
+// Export converts value to go value with default export options.
+//
+// Mapping:
+//
+// null,undefined => nil
+//
+// boolean => bool, number => float64, string => string, bigint => *big.Int
+//
+// Date => time.Time, ArrayBuffer and views => []byte (copied), RegExp => string
+//
+// Error => *ExportedError, Function => *ExportedFunction
+//
+// Array and Set => []interface{}, Map => []MapEntry, other objects => map[string]interface{} with own enumerable keys
+func (v *JsValue) Export() (interface{}, error) {
+	return v.ExportWithOptions(NewExportOptions())
+}
+
+// ExportWithOptions converts value to go value with given options.
+// Functions in returned value should be released by caller,they are released already if error returned.
+// Value is not consumed,even if it is a function.
+func (v *JsValue) ExportWithOptions(opt *ExportOptions) (result interface{}, err error) {
+	e := &exporter{
+		ctx: v.ctx,
+		opt: opt,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = toError(r)
+		}
+		if err != nil {
+			e.releaseFunctions()
+		}
+	}()
+	e.helper = v.ctx.helper(exportHelper)
+	return e.export(v)
+}
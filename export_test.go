@@ -0,0 +1,123 @@
+package v8js
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestExport(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	v := ctx.RunScript(`({
+	n: 1.5,
+	s: "str",
+	b: true,
+	u: undefined,
+	nil: null,
+	big: 12345678901234567890n,
+	date: new Date(1000),
+	buf: new Uint8Array([1, 2, 3]).buffer,
+	view: new Uint8Array([1, 2, 3, 4]).subarray(1, 3),
+	list: [1, "a"],
+	map: new Map([[{k: 1}, "v"]]),
+	set: new Set([1, 2]),
+	err: new TypeError("bad"),
+	fn: (a) => a * 2,
+})`, "export.js")
+	defer v.Release()
+	data, err := v.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := data.(map[string]interface{})
+	if m["n"] != 1.5 || m["s"] != "str" || m["b"] != true || m["u"] != nil || m["nil"] != nil {
+		t.Fatal(m)
+	}
+	expected, _ := new(big.Int).SetString("12345678901234567890", 10)
+	if m["big"].(*big.Int).Cmp(expected) != 0 {
+		t.Fatal(m["big"])
+	}
+	if !m["date"].(time.Time).Equal(time.UnixMilli(1000)) {
+		t.Fatal(m["date"])
+	}
+	if string(m["buf"].([]byte)) != "\x01\x02\x03" || string(m["view"].([]byte)) != "\x02\x03" {
+		t.Fatal(m["buf"], m["view"])
+	}
+	list := m["list"].([]interface{})
+	if len(list) != 2 || list[0] != 1.0 || list[1] != "a" {
+		t.Fatal(list)
+	}
+	entries := m["map"].([]MapEntry)
+	if len(entries) != 1 || entries[0].Key.(map[string]interface{})["k"] != 1.0 || entries[0].Value != "v" {
+		t.Fatal(entries)
+	}
+	if set := m["set"].([]interface{}); len(set) != 2 || set[1] != 2.0 {
+		t.Fatal(set)
+	}
+	if e := m["err"].(*ExportedError); e.Name != "TypeError" || e.Message != "bad" || e.Error() != "TypeError: bad" {
+		t.Fatal(e)
+	}
+	fn := m["fn"].(*ExportedFunction)
+	defer fn.Release()
+	if result := fn.Call(ctx.NewInt32(21).Consume()); result.Int32() != 42 {
+		t.Fatal(result.Int32())
+	}
+}
+
+func TestExportCycles(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	v := ctx.RunScript(`const shared = {a: 1}; const c = {shared, again: shared}; c.self = c; c`, "cycle.js")
+	defer v.Release()
+	_, err := v.Export()
+	if err != ErrExportCyclicValue {
+		t.Fatal(err)
+	}
+	opt := NewExportOptions()
+	opt.AllowCycles = true
+	data, err := v.ExportWithOptions(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := data.(map[string]interface{})
+	if m["self"] != nil || m["again"].(map[string]interface{})["a"] != 1.0 {
+		t.Fatal(m)
+	}
+	deep := ctx.RunScript(`[[[[1]]]]`, "deep.js")
+	defer deep.Release()
+	opt = NewExportOptions()
+	opt.MaxDepth = 3
+	if _, err = deep.ExportWithOptions(opt); err != ErrExportDepthExceeded {
+		t.Fatal(err)
+	}
+	opt.MaxDepth = 4
+	if _, err = deep.ExportWithOptions(opt); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportRelease(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	v := ctx.RunScript(`[() => 1, new Map([[() => 2, 1], [Symbol("bad"), 2]])]`, "export.js")
+	defer v.Release()
+	v.Export()
+	retained := ctx.RetainedValues()
+	if _, err := v.Export(); err == nil {
+		t.Fatal()
+	}
+	if ctx.RetainedValues() != retained {
+		t.Fatal(ctx.RetainedValues(), retained)
+	}
+	fn := ctx.RunScript(`(a) => a + 1`, "export.js")
+	defer fn.Release()
+	data, err := fn.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.(*ExportedFunction).Release()
+	if result := fn.Call(ctx.NullValue(), ctx.NewInt32(1).Consume()); result.Int32() != 2 {
+		t.Fatal(result.Int32())
+	}
+}
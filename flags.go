@@ -0,0 +1,126 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/herb-go/v8go"
+)
+
+var ErrFlagsAfterIsolate = errors.New("v8js: v8 flags must be set before first isolate created")
+var ErrInvalidFlag = errors.New("v8js: invalid v8 flag")
+
+var engineLocker sync.Mutex
+var engineStarted bool
+
+// startEngine marks engine started,flags can not be set after that.
+// It should be called by every path creating isolates.
+func startEngine() {
+	engineLocker.Lock()
+	defer engineLocker.Unlock()
+	engineStarted = true
+}
+
+// validFlag reports whether f is a flag known by v8 in form accepted by v8.
+// Dashes and underscores in flag names are interchangeable.
+func validFlag(f string) bool {
+	if !strings.HasPrefix(f, "--") || strings.ContainsAny(f, " \t\r\n") {
+		return false
+	}
+	name, value, hasValue := strings.Cut(f[2:], "=")
+	name = strings.ReplaceAll(name, "_", "-")
+	if boolean, ok := v8Flags[name]; ok {
+		if boolean {
+			return !hasValue
+		}
+		return hasValue && value != ""
+	}
+	if strings.HasPrefix(name, "no-") {
+		return v8Flags[name[3:]] && !hasValue
+	}
+	return false
+}
+
+// SetFlags sets process level v8 flags,such as "--max-old-space-size=512" or "--jitless".
+// For possible flags see https://github.com/v8/v8/blob/master/src/flags/flag-definitions.h .
+// Flags must start with "--",contain no whitespace and be known by linked v8,
+// boolean flags can be negated by "--no-" prefix and other flags require "=value",
+// ErrInvalidFlag returned otherwise.
+//
+// Flags must be set before first isolate created,by NewContext,NewIsolate or v8go directly,
+// ErrFlagsAfterIsolate returned otherwise,as most flags are read once when v8 initialized.
+func SetFlags(flags ...string) error {
+	for _, f := range flags {
+		if !validFlag(f) {
+			return ErrInvalidFlag
+		}
+	}
+	engineLocker.Lock()
+	defer engineLocker.Unlock()
+	// v8go initializes v8 when it creates first isolate.
+	if engineStarted || C.V8jsEngineInitialized() != 0 {
+		return ErrFlagsAfterIsolate
+	}
+	if len(flags) > 0 {
+		v8go.SetFlags(flags...)
+	}
+	return nil
+}
+
+// EngineOptions typed options of common v8 flags.
+type EngineOptions struct {
+	// MaxOldSpaceSize max size of old generation heap in megabytes,v8 default used if 0.
+	MaxOldSpaceSize int
+	// MaxSemiSpaceSize max size of young generation semi space in megabytes,v8 default used if 0.
+	MaxSemiSpaceSize int
+	// StackSize stack size limit of javascript in kilobytes,v8 default used if 0.
+	StackSize int
+	// ExposeGC exposes global gc function to javascript,for debugging only.
+	// Context.LowMemoryNotification and other gc notifications do not require it.
+	ExposeGC bool
+	// Jitless disables runtime code generation,for environments forbidding executable memory.
+	// Javascript runs in interpreter only,and WebAssembly is not available.
+	Jitless bool
+	// Harmony enables all completed harmony features.
+	Harmony bool
+	// Extra extra flags appended after typed flags.
+	Extra []string
+}
+
+// NewEngineOptions creates engine options using v8 defaults.
+func NewEngineOptions() *EngineOptions {
+	return &EngineOptions{}
+}
+
+// Flags returns v8 flags of options.
+func (o *EngineOptions) Flags() []string {
+	var flags []string
+	if o.MaxOldSpaceSize > 0 {
+		flags = append(flags, "--max-old-space-size="+strconv.Itoa(o.MaxOldSpaceSize))
+	}
+	if o.MaxSemiSpaceSize > 0 {
+		flags = append(flags, "--max-semi-space-size="+strconv.Itoa(o.MaxSemiSpaceSize))
+	}
+	if o.StackSize > 0 {
+		flags = append(flags, "--stack-size="+strconv.Itoa(o.StackSize))
+	}
+	if o.ExposeGC {
+		flags = append(flags, "--expose-gc")
+	}
+	if o.Jitless {
+		flags = append(flags, "--jitless")
+	}
+	if o.Harmony {
+		flags = append(flags, "--harmony")
+	}
+	return append(flags, o.Extra...)
+}
+
+// Configure sets v8 flags of engine options by SetFlags.
+func Configure(opt *EngineOptions) error {
+	return SetFlags(opt.Flags()...)
+}
@@ -0,0 +1,82 @@
+package v8js
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/herb-go/v8go"
+)
+
+func TestEngineOptions(t *testing.T) {
+	opt := NewEngineOptions()
+	opt.MaxOldSpaceSize = 512
+	opt.StackSize = 900
+	opt.ExposeGC = true
+	opt.Jitless = true
+	opt.Extra = []string{"--no-lazy"}
+	flags := strings.Join(opt.Flags(), " ")
+	if flags != "--max-old-space-size=512 --stack-size=900 --expose-gc --jitless --no-lazy" {
+		t.Fatal(flags)
+	}
+	if SetFlags("expose-gc") != ErrInvalidFlag || SetFlags("--expose-gc --jitless") != ErrInvalidFlag {
+		t.Fatal()
+	}
+	for _, f := range []string{"--unknown-flag", "--no-stack-size", "--stack-size", "--stack-size=", "--jitless=1", "--no-unknown"} {
+		if SetFlags(f) != ErrInvalidFlag {
+			t.Fatal(f)
+		}
+	}
+	NewContext().Close()
+	for _, f := range []string{"--expose-gc", "--no-lazy", "--max_old_space_size=512", "--stack-size=900"} {
+		if SetFlags(f) != ErrFlagsAfterIsolate {
+			t.Fatal(f)
+		}
+	}
+}
+
+// inFlagsProcess runs test in a new process and returns false,
+// or returns true if running in the new process,
+// as flags can only be set before first isolate created.
+func inFlagsProcess(t *testing.T) bool {
+	if os.Getenv("V8JS_TEST_FLAGS") == t.Name() {
+		return true
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^"+t.Name()+"$")
+	cmd.Env = append(os.Environ(), "V8JS_TEST_FLAGS="+t.Name())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatal(string(output))
+	}
+	return false
+}
+
+func TestSetFlags(t *testing.T) {
+	if !inFlagsProcess(t) {
+		return
+	}
+	opt := NewEngineOptions()
+	opt.ExposeGC = true
+	if err := Configure(opt); err != nil {
+		t.Fatal(err)
+	}
+	ctx := NewContext()
+	defer ctx.Close()
+	result := ctx.RunScript(`typeof gc`, "main.js")
+	defer result.Release()
+	if result.String() != "function" {
+		t.Fatal(result.String())
+	}
+}
+
+func TestSetFlagsAfterV8goIsolate(t *testing.T) {
+	if !inFlagsProcess(t) {
+		return
+	}
+	iso := v8go.NewIsolate()
+	defer iso.Dispose()
+	if err := SetFlags("--expose-gc"); err != ErrFlagsAfterIsolate {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,510 @@
+package v8js
+
+// v8Flags flags of V8 9.0 linked by v8go as listed by --help,mapped to whether flag is boolean.
+// Boolean flags can be negated by "--no-" prefix,other flags require a value.
+var v8Flags = map[string]bool{
+	"abort-on-contradictory-flags":               true,
+	"allow-overwriting-for-next-flag":            true,
+	"use-strict":                                 true,
+	"harmony":                                    true,
+	"harmony-shipping":                           true,
+	"harmony-regexp-sequence":                    true,
+	"harmony-weak-refs-with-cleanup-some":        true,
+	"harmony-import-assertions":                  true,
+	"harmony-top-level-await":                    true,
+	"harmony-relative-indexing-methods":          true,
+	"harmony-private-brand-checks":               true,
+	"harmony-class-static-blocks":                true,
+	"harmony-sharedarraybuffer":                  true,
+	"harmony-atomics":                            true,
+	"harmony-weak-refs":                          true,
+	"harmony-string-replaceall":                  true,
+	"harmony-logical-assignment":                 true,
+	"harmony-atomics-waitasync":                  true,
+	"harmony-regexp-match-indices":               true,
+	"builtin-subclassing":                        true,
+	"lite-mode":                                  true,
+	"future":                                     true,
+	"jitless":                                    true,
+	"assert-types":                               true,
+	"trace-code-dependencies":                    true,
+	"allocation-site-pretenuring":                true,
+	"page-promotion":                             true,
+	"page-promotion-threshold":                   false,
+	"trace-pretenuring":                          true,
+	"trace-pretenuring-statistics":               true,
+	"track-fields":                               true,
+	"track-double-fields":                        true,
+	"track-heap-object-fields":                   true,
+	"track-computed-fields":                      true,
+	"track-field-types":                          true,
+	"trace-block-coverage":                       true,
+	"trace-protector-invalidation":               true,
+	"feedback-normalization":                     true,
+	"enable-one-shot-optimization":               true,
+	"unbox-double-arrays":                        true,
+	"interrupt-budget":                           false,
+	"use-ic":                                     true,
+	"budget-for-feedback-vector-allocation":      false,
+	"scale-factor-for-feedback-allocation":       false,
+	"feedback-allocation-on-bytecode-size":       true,
+	"lazy-feedback-allocation":                   true,
+	"ignition-elide-noneffectful-bytecodes":      true,
+	"ignition-reo":                               true,
+	"ignition-filter-expression-positions":       true,
+	"ignition-share-named-property-feedback":     true,
+	"print-bytecode":                             true,
+	"enable-lazy-source-positions":               true,
+	"stress-lazy-source-positions":               true,
+	"print-bytecode-filter":                      false,
+	"trace-ignition-codegen":                     true,
+	"trace-ignition-dispatches":                  true,
+	"trace-ignition-dispatches-output-file":      false,
+	"trace-track-allocation-sites":               true,
+	"trace-migration":                            true,
+	"trace-generalization":                       true,
+	"turboprop":                                  true,
+	"turboprop-mid-tier-reg-alloc":               true,
+	"turboprop-as-toptier":                       true,
+	"interrupt-budget-scale-factor-for-top-tier": false,
+	"sparkplug":                                  true,
+	"always-sparkplug":                           true,
+	"sparkplug-filter":                           false,
+	"trace-baseline":                             true,
+	"concurrent-recompilation":                   true,
+	"trace-concurrent-recompilation":             true,
+	"concurrent-recompilation-queue-length":      false,
+	"concurrent-recompilation-delay":             false,
+	"block-concurrent-recompilation":             true,
+	"concurrent-inlining":                        true,
+	"stress-concurrent-inlining":                 true,
+	"turbo-direct-heap-access":                   true,
+	"max-serializer-nesting":                     false,
+	"trace-heap-broker-verbose":                  true,
+	"trace-heap-broker-memory":                   true,
+	"trace-heap-broker":                          true,
+	"stress-runs":                                false,
+	"deopt-every-n-times":                        false,
+	"print-deopt-stress":                         true,
+	"opt":                                        true,
+	"turbo-sp-frame-access":                      true,
+	"stress-turbo-late-spilling":                 true,
+	"turbo-filter":                               false,
+	"trace-turbo":                                true,
+	"trace-turbo-path":                           false,
+	"trace-turbo-filter":                         false,
+	"trace-turbo-graph":                          true,
+	"trace-turbo-scheduled":                      true,
+	"trace-turbo-cfg-file":                       false,
+	"trace-turbo-types":                          true,
+	"trace-turbo-scheduler":                      true,
+	"trace-turbo-reduction":                      true,
+	"trace-turbo-trimming":                       true,
+	"trace-turbo-jt":                             true,
+	"trace-turbo-ceq":                            true,
+	"trace-turbo-loop":                           true,
+	"trace-turbo-alloc":                          true,
+	"trace-all-uses":                             true,
+	"trace-representation":                       true,
+	"trace-turbo-stack-accesses":                 true,
+	"turbo-verify":                               true,
+	"turbo-verify-machine-graph":                 false,
+	"trace-verify-csa":                           true,
+	"csa-trap-on-node":                           false,
+	"turbo-stats":                                true,
+	"turbo-stats-nvp":                            true,
+	"turbo-stats-wasm":                           true,
+	"turbo-splitting":                            true,
+	"function-context-specialization":            true,
+	"turbo-inlining":                             true,
+	"max-inlined-bytecode-size":                  false,
+	"max-inlined-bytecode-size-cumulative":       false,
+	"max-inlined-bytecode-size-absolute":         false,
+	"reserve-inline-budget-scale-factor":         false,
+	"max-inlined-bytecode-size-small":            false,
+	"max-optimized-bytecode-size":                false,
+	"min-inlining-frequency":                     false,
+	"polymorphic-inlining":                       true,
+	"stress-inline":                              true,
+	"trace-turbo-inlining":                       true,
+	"turbo-inline-array-builtins":                true,
+	"use-osr":                                    true,
+	"trace-osr":                                  true,
+	"analyze-environment-liveness":               true,
+	"trace-environment-liveness":                 true,
+	"turbo-load-elimination":                     true,
+	"trace-turbo-load-elimination":               true,
+	"turbo-profiling":                            true,
+	"turbo-profiling-verbose":                    true,
+	"turbo-profiling-log-builtins":               true,
+	"turbo-verify-allocation":                    true,
+	"turbo-move-optimization":                    true,
+	"turbo-jt":                                   true,
+	"turbo-loop-peeling":                         true,
+	"turbo-loop-variable":                        true,
+	"turbo-loop-rotation":                        true,
+	"turbo-cf-optimization":                      true,
+	"turbo-escape":                               true,
+	"turbo-allocation-folding":                   true,
+	"turbo-instruction-scheduling":               true,
+	"turbo-stress-instruction-scheduling":        true,
+	"turbo-store-elimination":                    true,
+	"trace-store-elimination":                    true,
+	"turbo-rewrite-far-jumps":                    true,
+	"stress-gc-during-compilation":               true,
+	"turbo-fast-api-calls":                       true,
+	"reuse-opt-code-count":                       false,
+	"turbo-dynamic-map-checks":                   true,
+	"turbo-compress-translation-arrays":          true,
+	"turbo-inline-js-wasm-calls":                 true,
+	"turbo-nci":                                  true,
+	"print-nci-code":                             true,
+	"trace-turbo-nci":                            true,
+	"turbo-collect-feedback-in-generic-lowering": true,
+	"isolate-script-cache-ageing":                true,
+	"optimize-for-size":                          true,
+	"untrusted-code-mitigations":                 true,
+	"wasm-generic-wrapper":                       true,
+	"expose-wasm":                                true,
+	"wasm-num-compilation-tasks":                 false,
+	"wasm-write-protect-code-memory":             true,
+	"wasm-async-compilation":                     true,
+	"wasm-test-streaming":                        true,
+	"wasm-max-mem-pages":                         false,
+	"wasm-max-table-size":                        false,
+	"wasm-max-code-space":                        false,
+	"wasm-tier-up":                               true,
+	"wasm-dynamic-tiering":                       true,
+	"trace-wasm-ast-start":                       false,
+	"trace-wasm-ast-end":                         false,
+	"liftoff":                                    true,
+	"liftoff-only":                               true,
+	"experimental-liftoff-extern-ref":            true,
+	"trace-wasm-memory":                          true,
+	"wasm-tier-mask-for-testing":                 false,
+	"validate-asm":                               true,
+	"suppress-asm-messages":                      true,
+	"trace-asm-time":                             true,
+	"trace-asm-scanner":                          true,
+	"trace-asm-parser":                           true,
+	"stress-validate-asm":                        true,
+	"dump-wasm-module-path":                      false,
+	"experimental-wasm-compilation-hints":        true,
+	"experimental-wasm-gc":                       true,
+	"experimental-wasm-typed-funcref":            true,
+	"experimental-wasm-memory64":                 true,
+	"experimental-wasm-eh":                       true,
+	"experimental-wasm-reftypes":                 true,
+	"experimental-wasm-return-call":              true,
+	"experimental-wasm-simd":                     true,
+	"experimental-wasm-threads":                  true,
+	"experimental-wasm-type-reflection":          true,
+	"experimental-wasm-mv":                       true,
+	"wasm-staging":                               true,
+	"wasm-opt":                                   true,
+	"wasm-bounds-checks":                         true,
+	"wasm-stack-checks":                          true,
+	"wasm-math-intrinsics":                       true,
+	"wasm-loop-unrolling":                        true,
+	"wasm-trap-handler":                          true,
+	"wasm-fuzzer-gen-test":                       true,
+	"print-wasm-code":                            true,
+	"print-wasm-code-function-index":             false,
+	"print-wasm-stub-code":                       true,
+	"asm-wasm-lazy-compilation":                  true,
+	"wasm-lazy-compilation":                      true,
+	"wasm-lazy-validation":                       true,
+	"wasm-simd-post-mvp":                         true,
+	"wasm-simd-ssse3-codegen":                    true,
+	"wasm-code-gc":                               true,
+	"trace-wasm-code-gc":                         true,
+	"stress-wasm-code-gc":                        true,
+	"wasm-max-initial-code-space-reservation":    false,
+	"experimental-wasm-allow-huge-modules":       true,
+	"stress-sampling-allocation-profiler":        false,
+	"lazy-new-space-shrinking":                   true,
+	"min-semi-space-size":                        false,
+	"max-semi-space-size":                        false,
+	"semi-space-growth-factor":                   false,
+	"max-old-space-size":                         false,
+	"max-heap-size":                              false,
+	"initial-heap-size":                          false,
+	"huge-max-old-generation-size":               true,
+	"initial-old-space-size":                     false,
+	"global-gc-scheduling":                       true,
+	"gc-global":                                  true,
+	"random-gc-interval":                         false,
+	"gc-interval":                                false,
+	"retain-maps-for-n-gc":                       false,
+	"trace-gc":                                   true,
+	"trace-gc-nvp":                               true,
+	"trace-gc-ignore-scavenger":                  true,
+	"trace-idle-notification":                    true,
+	"trace-idle-notification-verbose":            true,
+	"trace-gc-verbose":                           true,
+	"trace-gc-freelists":                         true,
+	"trace-gc-freelists-verbose":                 true,
+	"trace-evacuation-candidates":                true,
+	"trace-allocations-origins":                  true,
+	"trace-allocation-stack-interval":            false,
+	"trace-duplicate-threshold-kb":               false,
+	"trace-fragmentation":                        true,
+	"trace-fragmentation-verbose":                true,
+	"minor-mc-trace-fragmentation":               true,
+	"trace-evacuation":                           true,
+	"trace-mutator-utilization":                  true,
+	"incremental-marking":                        true,
+	"incremental-marking-wrappers":               true,
+	"incremental-marking-task":                   true,
+	"incremental-marking-soft-trigger":           false,
+	"incremental-marking-hard-trigger":           false,
+	"trace-unmapper":                             true,
+	"parallel-scavenge":                          true,
+	"scavenge-task":                              true,
+	"scavenge-task-trigger":                      false,
+	"scavenge-separate-stack-scanning":           true,
+	"trace-parallel-scavenge":                    true,
+	"write-protect-code-memory":                  true,
+	"concurrent-marking":                         true,
+	"concurrent-array-buffer-sweeping":           true,
+	"stress-concurrent-allocation":               true,
+	"parallel-marking":                           true,
+	"ephemeron-fixpoint-iterations":              false,
+	"trace-concurrent-marking":                   true,
+	"concurrent-sweeping":                        true,
+	"parallel-compaction":                        true,
+	"parallel-pointer-update":                    true,
+	"detect-ineffective-gcs-near-heap-limit":     true,
+	"trace-incremental-marking":                  true,
+	"trace-stress-marking":                       true,
+	"trace-stress-scavenge":                      true,
+	"track-gc-object-stats":                      true,
+	"trace-gc-object-stats":                      true,
+	"trace-zone-stats":                           true,
+	"zone-stats-tolerance":                       false,
+	"trace-zone-type-stats":                      true,
+	"track-retaining-path":                       true,
+	"gc-stats":                                   false,
+	"track-detached-contexts":                    true,
+	"trace-detached-contexts":                    true,
+	"move-object-start":                          true,
+	"memory-reducer":                             true,
+	"memory-reducer-for-small-heaps":             true,
+	"heap-growing-percent":                       false,
+	"v8-os-page-size":                            false,
+	"allocation-buffer-parking":                  true,
+	"always-compact":                             true,
+	"never-compact":                              true,
+	"compact-code-space":                         true,
+	"flush-bytecode":                             true,
+	"stress-flush-bytecode":                      true,
+	"trace-flush-bytecode":                       true,
+	"use-marking-progress-bar":                   true,
+	"stress-per-context-marking-worklist":        true,
+	"force-marking-deque-overflows":              true,
+	"stress-compaction":                          true,
+	"stress-compaction-random":                   true,
+	"stress-incremental-marking":                 true,
+	"fuzzer-gc-analysis":                         true,
+	"stress-marking":                             false,
+	"stress-scavenge":                            false,
+	"reclaim-unmodified-wrappers":                true,
+	"gc-experiment-less-compaction":              true,
+	"disable-abortjs":                            true,
+	"randomize-all-allocations":                  true,
+	"manual-evacuation-candidates-selection":     true,
+	"fast-promotion-new-space":                   true,
+	"clear-free-memory":                          true,
+	"debug-code":                                 true,
+	"code-comments":                              true,
+	"enable-sse3":                                true,
+	"enable-ssse3":                               true,
+	"enable-sse4-1":                              true,
+	"enable-sse4-2":                              true,
+	"enable-sahf":                                true,
+	"enable-avx":                                 true,
+	"enable-avx2":                                true,
+	"enable-fma3":                                true,
+	"enable-bmi1":                                true,
+	"enable-bmi2":                                true,
+	"enable-lzcnt":                               true,
+	"enable-popcnt":                              true,
+	"arm-arch":                                   false,
+	"force-long-branches":                        true,
+	"mcpu":                                       false,
+	"partial-constant-pool":                      true,
+	"sim-arm64-optional-features":                false,
+	"debug-riscv":                                true,
+	"disable-riscv-constant-pool":                true,
+	"enable-source-at-csa-bind":                  true,
+	"enable-armv7":                               true,
+	"enable-vfp3":                                true,
+	"enable-32dregs":                             true,
+	"enable-neon":                                true,
+	"enable-sudiv":                               true,
+	"enable-armv8":                               true,
+	"enable-regexp-unaligned-accesses":           true,
+	"script-streaming":                           true,
+	"stress-background-compile":                  true,
+	"finalize-streaming-on-background":           true,
+	"disable-old-api-accessors":                  true,
+	"expose-gc":                                  true,
+	"expose-gc-as":                               false,
+	"expose-externalize-string":                  true,
+	"expose-trigger-failure":                     true,
+	"stack-trace-limit":                          false,
+	"builtins-in-stack-traces":                   true,
+	"experimental-stack-trace-frames":            true,
+	"disallow-code-generation-from-strings":      true,
+	"expose-async-hooks":                         true,
+	"expose-cputracemark-as":                     false,
+	"allow-unsafe-function-constructor":          true,
+	"force-slow-path":                            true,
+	"test-small-max-function-context-stub-size":  true,
+	"inline-new":                                 true,
+	"trace":                                      true,
+	"trace-wasm":                                 true,
+	"lazy":                                       true,
+	"max-lazy":                                   true,
+	"trace-opt":                                  true,
+	"trace-opt-verbose":                          true,
+	"trace-opt-stats":                            true,
+	"trace-deopt":                                true,
+	"log-deopt":                                  true,
+	"trace-deopt-verbose":                        true,
+	"trace-file-names":                           true,
+	"always-opt":                                 true,
+	"always-osr":                                 true,
+	"prepare-always-opt":                         true,
+	"trace-serializer":                           true,
+	"compilation-cache":                          true,
+	"cache-prototype-transitions":                true,
+	"parallel-compile-tasks":                     true,
+	"compiler-dispatcher":                        true,
+	"trace-compiler-dispatcher":                  true,
+	"cpu-profiler-sampling-interval":             false,
+	"trace-side-effect-free-debug-evaluate":      true,
+	"hard-abort":                                 true,
+	"log-colour":                                 true,
+	"expose-inspector-scripts":                   true,
+	"stack-size":                                 false,
+	"max-stack-trace-source-length":              false,
+	"clear-exceptions-on-js-entry":               true,
+	"histogram-interval":                         false,
+	"heap-profiler-trace-objects":                true,
+	"heap-profiler-use-embedder-graph":           true,
+	"heap-snapshot-string-limit":                 false,
+	"heap-profiler-show-hidden-objects":          true,
+	"sampling-heap-profiler-suppress-randomness": true,
+	"use-idle-notification":                      true,
+	"log-ic":                                     true,
+	"trace-ic":                                   true,
+	"max-valid-polymorphic-map-count":            false,
+	"native-code-counters":                       true,
+	"super-ic":                                   true,
+	"thin-strings":                               true,
+	"trace-prototype-users":                      true,
+	"trace-for-in-enumerate":                     true,
+	"log-maps":                                   true,
+	"log-maps-details":                           true,
+	"allow-natives-syntax":                       true,
+	"allow-natives-for-differential-fuzzing":     true,
+	"parse-only":                                 true,
+	"async-stack-traces":                         true,
+	"stack-trace-on-illegal":                     true,
+	"abort-on-uncaught-exception":                true,
+	"correctness-fuzzer-suppressions":            true,
+	"randomize-hashes":                           true,
+	"rehash-snapshot":                            true,
+	"hash-seed":                                  false,
+	"random-seed":                                false,
+	"fuzzer-random-seed":                         false,
+	"trace-rail":                                 true,
+	"print-all-exceptions":                       true,
+	"detailed-error-stack-trace":                 true,
+	"adjust-os-scheduling-parameters":            true,
+	"experimental-flush-embedded-blob-icache":    true,
+	"runtime-call-stats":                         true,
+	"rcs":                                        true,
+	"rcs-cpu-time":                               true,
+	"profile-deserialization":                    true,
+	"serialization-statistics":                   true,
+	"regexp-optimization":                        true,
+	"regexp-mode-modifiers":                      true,
+	"regexp-interpret-all":                       true,
+	"regexp-tier-up":                             true,
+	"regexp-tier-up-ticks":                       false,
+	"regexp-peephole-optimization":               true,
+	"trace-regexp-peephole-optimization":         true,
+	"trace-regexp-bytecodes":                     true,
+	"trace-regexp-assembler":                     true,
+	"trace-regexp-parser":                        true,
+	"trace-regexp-tier-up":                       true,
+	"enable-experimental-regexp-engine":          true,
+	"default-to-experimental-regexp-engine":      true,
+	"trace-experimental-regexp-engine":           true,
+	"enable-experimental-regexp-engine-on-excessive-backtracks": true,
+	"regexp-backtracks-before-fallback":                         false,
+	"testing-bool-flag":                                         true,
+	"testing-maybe-bool-flag":                                   true,
+	"testing-int-flag":                                          false,
+	"testing-float-flag":                                        false,
+	"testing-string-flag":                                       false,
+	"testing-prng-seed":                                         false,
+	"testing-d8-test-runner":                                    true,
+	"fuzzing":                                                   true,
+	"embedded-src":                                              false,
+	"embedded-variant":                                          false,
+	"startup-src":                                               false,
+	"startup-blob":                                              false,
+	"target-arch":                                               false,
+	"target-os":                                                 false,
+	"target-is-simulator":                                       true,
+	"turbo-profiling-log-file":                                  false,
+	"text-is-readable":                                          true,
+	"trace-minor-mc-parallel-marking":                           true,
+	"minor-mc":                                                  true,
+	"help":                                                      true,
+	"dump-counters":                                             true,
+	"dump-counters-nvp":                                         true,
+	"use-external-strings":                                      true,
+	"map-counters":                                              false,
+	"mock-arraybuffer-allocator":                                true,
+	"mock-arraybuffer-allocator-limit":                          false,
+	"multi-mapped-mock-allocator":                               true,
+	"logfile":                                                   false,
+	"logfile-per-isolate":                                       true,
+	"log":                                                       true,
+	"log-all":                                                   true,
+	"log-api":                                                   true,
+	"log-code":                                                  true,
+	"log-code-disassemble":                                      true,
+	"log-handles":                                               true,
+	"log-suspect":                                               true,
+	"log-source-code":                                           true,
+	"log-function-events":                                       true,
+	"detailed-line-info":                                        true,
+	"prof-sampling-interval":                                    false,
+	"prof-cpp":                                                  true,
+	"prof-browser-mode":                                         true,
+	"prof":                                                      true,
+	"ll-prof":                                                   true,
+	"perf-basic-prof":                                           true,
+	"perf-basic-prof-only-functions":                            true,
+	"perf-prof":                                                 true,
+	"perf-prof-annotate-wasm":                                   true,
+	"perf-prof-delete-file":                                     true,
+	"perf-prof-unwinding-info":                                  true,
+	"gc-fake-mmap":                                              false,
+	"log-internal-timer-events":                                 true,
+	"redirect-code-traces":                                      true,
+	"redirect-code-traces-to":                                   false,
+	"print-opt-source":                                          true,
+	"vtune-prof-annotate-wasm":                                  true,
+	"win64-unwinding-info":                                      true,
+	"interpreted-frames-native-stack":                           true,
+	"predictable":                                               true,
+	"predictable-gc-schedule":                                   true,
+	"single-threaded":                                           true,
+	"single-threaded-gc":                                        true,
+}
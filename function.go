@@ -0,0 +1,147 @@
+package v8js
+
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import (
+	"runtime"
+	"strconv"
+	"unsafe"
+
+	"github.com/herb-go/v8go"
+)
+
+var isConstructorHelper = registerHelper("function-isconstructor.js", `((construct, String) => function(fn) {
+	try {
+		construct(String, [], fn);
+		return true;
+	} catch (e) {
+		return false;
+	}
+})(Reflect.construct, String)`)
+
+var functionToStringHelper = registerHelper("function-tostring.js", `((apply, toString) => function(fn) {
+	return apply(toString, fn, []);
+})(Reflect.apply, Function.prototype.toString)`)
+
+func mustAsFunction(v *v8go.Value) *v8go.Function {
+	fn, err := v.AsFunction()
+	if err != nil {
+		panic(err)
+	}
+	return fn
+}
+
+// New calls value as a constructor with given arguments,same as "new Foo(args)" in javascript.
+func (v *JsValue) New(args ...*Consumed) *JsValue {
+	if v.raw == nil {
+		return nil
+	}
+	fn := mustAsFunction(v.export())
+	fnargs := make([]v8go.Valuer, len(args))
+	for i, val := range args {
+		fnargs[i] = val.export()
+	}
+	v.ctx.enter()
+	defer v.ctx.exit()
+	obj, err := fn.NewInstance(fnargs...)
+	var result *JsValue
+	if err == nil {
+		result = v.ctx.Wrap(obj.Value)
+	}
+	err = v.ctx.leave(result, err)
+	if err != nil {
+		panic(err)
+	}
+	for i := range args {
+		args[i].Release()
+	}
+	return result
+}
+
+// FunctionName returns the name property of function.
+func (v *JsValue) FunctionName() string {
+	name := v.Get("name")
+	defer name.Release()
+	if name.IsNullOrUndefined() {
+		return ""
+	}
+	return name.String()
+}
+
+// Length returns the number of arguments expected by function.
+func (v *JsValue) Length() int {
+	length := v.Get("length")
+	defer length.Release()
+	if length.IsNullOrUndefined() {
+		return 0
+	}
+	return int(length.Integer())
+}
+
+// SourceText returns the source code of function,using Function.prototype.toString.
+func (v *JsValue) SourceText() string {
+	h := v.ctx.helper(functionToStringHelper)
+	result := h.Call(v.ctx.NullValue(), v.ConsumeReuseble().Consume())
+	defer result.Release()
+	return result.String()
+}
+
+// SourceMapURL returns the source map url of the script which function defined in.
+// Return empty string if no source map url found.
+func (v *JsValue) SourceMapURL() string {
+	url := v.ctx.Wrap(mustAsFunction(v.export()).SourceMapUrl())
+	runtime.KeepAlive(v)
+	defer url.Release()
+	if url.IsNullOrUndefined() {
+		return ""
+	}
+	return url.String()
+}
+
+// FunctionLocation returns the script origin of function,with 1-based line and column where function defined.
+// Line and column point to the parameter list of function,classes report location of their constructors.
+// Location is mapped to original source if source map of script registered.
+// Return nil if value is not a function or function is not defined in script,such as builtins and Go callbacks.
+func (v *JsValue) FunctionLocation() *StackFrame {
+	loc := C.V8jsFunctionGetLocation(v.ctx.nativeContext(), v.native())
+	runtime.KeepAlive(v)
+	defer C.free(unsafe.Pointer(loc.name))
+	defer C.free(unsafe.Pointer(loc.resource))
+	if loc.name == nil || loc.line < 0 {
+		return nil
+	}
+	frame := &StackFrame{
+		Function: C.GoString(loc.name),
+		Script:   C.GoString(loc.resource),
+		Line:     int(loc.line) + 1,
+		Column:   int(loc.column) + 1,
+	}
+	if mapped := v.ctx.mapLocation(frame.Script + ":" + strconv.Itoa(frame.Line) + ":" + strconv.Itoa(frame.Column)); mapped != "" {
+		parseStackLocation(frame, mapped)
+	}
+	return frame
+}
+
+func (v *JsValue) IsAsyncFunction() bool {
+	result := v.export().IsAsyncFunction()
+	runtime.KeepAlive(v)
+	return result
+}
+func (v *JsValue) IsGeneratorFunction() bool {
+	result := v.export().IsGeneratorFunction()
+	runtime.KeepAlive(v)
+	return result
+}
+
+// IsConstructor checks if value can be called with "new".
+// Function will not be invoked.
+func (v *JsValue) IsConstructor() bool {
+	if !v.IsFunction() {
+		return false
+	}
+	h := v.ctx.helper(isConstructorHelper)
+	result := h.Call(v.ctx.NullValue(), v.ConsumeReuseble().Consume())
+	defer result.Release()
+	return result.Boolean()
+}
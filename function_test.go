@@ -0,0 +1,56 @@
+package v8js
+
+import "testing"
+
+func TestFunctionMetadata(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	fns := ctx.RunScript(`
+class Foo {
+	constructor(a, b) { this.sum = a + b }
+}
+async function asyncfn(a) {}
+function* genfn() {}
+const arrow = (a, b, c) => a;
+[Foo, asyncfn, genfn, arrow]
+//# sourceMappingURL=main.js.map`, "main.js")
+	defer fns.Release()
+	foo := fns.GetIdx(0)
+	if foo.FunctionName() != "Foo" || foo.Length() != 2 || !foo.IsConstructor() {
+		t.Fatal(foo.FunctionName(), foo.Length())
+	}
+	obj := foo.New(ctx.NewInt32(1).Consume(), ctx.NewInt32(2).Consume())
+	if obj.Get("sum").Int32() != 3 {
+		t.Fatal()
+	}
+	if foo.SourceText() != "class Foo {\n\tconstructor(a, b) { this.sum = a + b }\n}" {
+		t.Fatal(foo.SourceText())
+	}
+	if foo.SourceMapURL() != "main.js.map" {
+		t.Fatal(foo.SourceMapURL())
+	}
+	if loc := foo.FunctionLocation(); loc == nil || loc.String() != "Foo (main.js:3:13)" {
+		t.Fatal(loc)
+	}
+	if loc := fns.GetIdx(3).FunctionLocation(); loc == nil || loc.String() != "arrow (main.js:7:15)" {
+		t.Fatal(loc)
+	}
+	if fns.FunctionLocation() != nil || ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed { return nil }).FunctionLocation() != nil {
+		t.Fatal()
+	}
+	asyncfn := fns.GetIdx(1)
+	if !asyncfn.IsAsyncFunction() || asyncfn.IsGeneratorFunction() || asyncfn.IsConstructor() {
+		t.Fatal()
+	}
+	genfn := fns.GetIdx(2)
+	if !genfn.IsGeneratorFunction() || genfn.IsAsyncFunction() || genfn.IsConstructor() {
+		t.Fatal()
+	}
+	arrow := fns.GetIdx(3)
+	if arrow.IsConstructor() || arrow.Length() != 3 || arrow.FunctionName() != "arrow" {
+		t.Fatal()
+	}
+	if ctx.NewString("str").IsConstructor() {
+		t.Fatal()
+	}
+}
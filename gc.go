@@ -0,0 +1,79 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"sync/atomic"
+	"time"
+)
+
+// GoGCOnClose runs Go garbage collection when context closed.
+// Values of context are freed by v8go when context closed,
+// long running hosts closing many contexts can set it to false to avoid the cost.
+var GoGCOnClose = true
+
+// MemoryPressureLevel level of memory pressure notified by Context.MemoryPressure.
+type MemoryPressureLevel int
+
+const (
+	// MemoryPressureNone no memory pressure.
+	MemoryPressureNone MemoryPressureLevel = iota
+	// MemoryPressureModerate moderate memory pressure,v8 collects garbage more aggressively.
+	MemoryPressureModerate
+	// MemoryPressureCritical critical memory pressure,v8 collects full heap immediately.
+	MemoryPressureCritical
+)
+
+var liveValues atomic.Int64
+
+// LiveValues returns count of wrapped values not released yet in all contexts.
+func LiveValues() int64 {
+	return liveValues.Load()
+}
+
+// LiveValues returns count of wrapped values not released yet in context.
+// Values not released are freed when context closed or reset.
+func (c *Context) LiveValues() int64 {
+	return c.liveValues.Load()
+}
+
+// RetainedValues returns count of v8 values retained by context,
+// including values used internally by v8js.
+func (c *Context) RetainedValues() int {
+	return c.Raw.RetainedValueCount()
+}
+
+func (c *Context) trackValue() {
+	c.liveValues.Add(1)
+	liveValues.Add(1)
+}
+
+func (c *Context) untrackValue() {
+	c.liveValues.Add(-1)
+	liveValues.Add(-1)
+}
+
+// untrackAllValues untracks values freed by closing or resetting context.
+func (c *Context) untrackAllValues() {
+	liveValues.Add(-c.liveValues.Swap(0))
+}
+
+// LowMemoryNotification notifies isolate of context that host is low on memory,
+// v8 runs full garbage collection of isolate.
+func (c *Context) LowMemoryNotification() {
+	C.V8jsLowMemoryNotification(C.V8jsIsolatePtr(nativePtr(c.Raw.Isolate())))
+}
+
+// MemoryPressure notifies memory pressure of given level to isolate of context,
+// v8 collects garbage accordingly.
+func (c *Context) MemoryPressure(level MemoryPressureLevel) {
+	C.V8jsMemoryPressureNotification(C.V8jsIsolatePtr(nativePtr(c.Raw.Isolate())), C.int(level))
+}
+
+// IdleNotification notifies isolate of context is idle for given time,
+// usually called by hosts between tasks.
+// V8 runs garbage collection work which can be finished before idle time passed.
+// Returns true if there is no more garbage collection work to do in idle time.
+func (c *Context) IdleNotification(idle time.Duration) bool {
+	return C.V8jsIdleNotification(C.V8jsIsolatePtr(nativePtr(c.Raw.Isolate())), C.double(idle.Seconds())) != 0
+}
@@ -0,0 +1,56 @@
+package v8js
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLiveValues(t *testing.T) {
+	ctx := NewContext()
+	live := ctx.LiveValues()
+	total := LiveValues()
+	v := ctx.NewObject()
+	if ctx.LiveValues() != live+1 || LiveValues() != total+1 || ctx.RetainedValues() == 0 {
+		t.Fatal(ctx.LiveValues(), LiveValues())
+	}
+	v.Release()
+	v.Release()
+	if ctx.LiveValues() != live {
+		t.Fatal(ctx.LiveValues())
+	}
+	stale := ctx.NewString("leaked")
+	ctx.Reset()
+	stale.Release()
+	if ctx.LiveValues() != live {
+		t.Fatal(ctx.LiveValues(), live)
+	}
+	closed := ctx.NewObject()
+	ctx.Close()
+	closed.Release()
+	if ctx.LiveValues() != 0 || LiveValues() != total-live {
+		t.Fatal(ctx.LiveValues(), LiveValues())
+	}
+}
+
+func TestGC(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	ctx.RunScript(`globalThis.garbage = []; for (let i = 0; i < 100000; i++) { garbage.push({ i }) }; garbage = null`, "main.js").Release()
+	ctx.MemoryPressure(MemoryPressureModerate)
+	started := time.Now()
+	ctx.IdleNotification(10 * time.Millisecond)
+	if time.Since(started) > time.Second {
+		t.Fatal(time.Since(started))
+	}
+	before := ctx.Isolate().Raw.GetHeapStatistics().UsedHeapSize
+	ctx.LowMemoryNotification()
+	after := ctx.Isolate().Raw.GetHeapStatistics().UsedHeapSize
+	if after > before {
+		t.Fatal(before, after)
+	}
+	result := ctx.RunScript(`typeof gc`, "main.js")
+	defer result.Release()
+	if result.String() != "undefined" {
+		t.Fatal(result.String())
+	}
+}
@@ -3,6 +3,7 @@ module github.com/jarlyyn/v8js
 go 1.20
 
 require (
+	github.com/google/pprof v0.0.0-20230207041349-798e818bf904
 	github.com/herb-go/herbplugin v0.0.0-20251105104000-ca813dae4fd7
 	github.com/herb-go/plugins v0.0.0-20251105104654-1ad36b135de5
 	github.com/herb-go/v8go v0.0.0-20251114063147-99ce5e20c7c0
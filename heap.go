@@ -0,0 +1,141 @@
+package v8js
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/herb-go/v8go"
+)
+
+// WriteHeapSnapshot writes heap snapshot of context isolate to writer.
+// Output is in .heapsnapshot format which can be loaded by Chrome DevTools.
+func (c *Context) WriteHeapSnapshot(w io.Writer) error {
+	f, err := os.CreateTemp("", "v8js-*.heapsnapshot")
+	if err != nil {
+		return err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	v8go.WriteHeapSnapshot(c.Raw.Isolate(), path)
+	f, err = os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if stat.Size() == 0 {
+		return errors.New("v8js: failed to take heap snapshot")
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// HeapStatistics returns heap statistics of context isolate.
+func (c *Context) HeapStatistics() v8go.HeapStatistics {
+	return c.Raw.Isolate().GetHeapStatistics()
+}
+
+// HeapObjectStat object count and size of a constructor in heap snapshot.
+type HeapObjectStat struct {
+	// Constructor constructor name of objects,or node type in parentheses for non object nodes,like "(closure)".
+	Constructor string
+	Count       int
+	SelfSize    int64
+}
+
+// HeapSummary summary of heap snapshot.
+type HeapSummary struct {
+	TotalCount int
+	TotalSize  int64
+	// Objects stats sorted by self size descending.
+	Objects []*HeapObjectStat
+}
+
+type heapSnapshot struct {
+	Snapshot struct {
+		Meta struct {
+			NodeFields []string          `json:"node_fields"`
+			NodeTypes  []json.RawMessage `json:"node_types"`
+		} `json:"meta"`
+	} `json:"snapshot"`
+	Nodes   []int64  `json:"nodes"`
+	Strings []string `json:"strings"`
+}
+
+// ParseHeapSummary parses summary from heap snapshot data in .heapsnapshot format.
+func ParseHeapSummary(r io.Reader) (*HeapSummary, error) {
+	snapshot := &heapSnapshot{}
+	err := json.NewDecoder(r).Decode(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	meta := snapshot.Snapshot.Meta
+	typeField, nameField, sizeField := -1, -1, -1
+	for i, field := range meta.NodeFields {
+		switch field {
+		case "type":
+			typeField = i
+		case "name":
+			nameField = i
+		case "self_size":
+			sizeField = i
+		}
+	}
+	if typeField < 0 || nameField < 0 || sizeField < 0 || len(meta.NodeTypes) <= typeField {
+		return nil, errors.New("v8js: invalid heap snapshot meta")
+	}
+	var types []string
+	err = json.Unmarshal(meta.NodeTypes[typeField], &types)
+	if err != nil {
+		return nil, err
+	}
+	stats := map[string]*HeapObjectStat{}
+	summary := &HeapSummary{}
+	width := len(meta.NodeFields)
+	for i := 0; i+width <= len(snapshot.Nodes); i += width {
+		node := snapshot.Nodes[i : i+width]
+		nodetype, name := node[typeField], node[nameField]
+		if nodetype < 0 || int(nodetype) >= len(types) || name < 0 || int(name) >= len(snapshot.Strings) {
+			return nil, errors.New("v8js: invalid heap snapshot node")
+		}
+		constructor := "(" + types[nodetype] + ")"
+		if types[nodetype] == "object" {
+			constructor = snapshot.Strings[name]
+		}
+		stat := stats[constructor]
+		if stat == nil {
+			stat = &HeapObjectStat{Constructor: constructor}
+			stats[constructor] = stat
+			summary.Objects = append(summary.Objects, stat)
+		}
+		stat.Count++
+		stat.SelfSize += node[sizeField]
+		summary.TotalCount++
+		summary.TotalSize += node[sizeField]
+	}
+	sort.SliceStable(summary.Objects, func(i, j int) bool {
+		return summary.Objects[i].SelfSize > summary.Objects[j].SelfSize
+	})
+	return summary, nil
+}
+
+// HeapSummary takes heap snapshot and returns its summary.
+func (c *Context) HeapSummary() (*HeapSummary, error) {
+	buf := bytes.NewBuffer(nil)
+	err := c.WriteHeapSnapshot(buf)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHeapSummary(buf)
+}
@@ -0,0 +1,36 @@
+package v8js
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestHeapSnapshot(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	ctx.RunScript(`class LeakedItem {}; globalThis.leaked = []; for (let i = 0; i < 100; i++) { leaked.push(new LeakedItem()) }`, "leak.js")
+	buf := bytes.NewBuffer(nil)
+	if err := ctx.WriteHeapSnapshot(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Fatal(buf.Len())
+	}
+	summary, err := ctx.HeapSummary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found *HeapObjectStat
+	for _, stat := range summary.Objects {
+		if stat.Constructor == "LeakedItem" {
+			found = stat
+		}
+	}
+	if found == nil || found.Count != 100 || summary.TotalCount == 0 || summary.TotalSize == 0 {
+		t.Fatal(found, summary.TotalCount)
+	}
+	if ctx.HeapStatistics().UsedHeapSize == 0 {
+		t.Fatal()
+	}
+}
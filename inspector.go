@@ -0,0 +1,335 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"runtime"
+	"sync"
+	"unicode/utf16"
+	"unsafe"
+)
+
+var inspectorsLocker sync.Mutex
+var inspectors = map[int]*Inspector{}
+var inspectorSessions = map[int]*InspectorSession{}
+var inspectorSeq int
+
+func nextInspectorID() int {
+	inspectorsLocker.Lock()
+	defer inspectorsLocker.Unlock()
+	inspectorSeq++
+	return inspectorSeq
+}
+
+func inspectorByID(id int) *Inspector {
+	inspectorsLocker.Lock()
+	defer inspectorsLocker.Unlock()
+	return inspectors[id]
+}
+
+func inspectorSessionByID(id int) *InspectorSession {
+	inspectorsLocker.Lock()
+	defer inspectorsLocker.Unlock()
+	return inspectorSessions[id]
+}
+
+// Inspector debugs javascript of context with Chrome DevTools protocol of v8 inspector.
+// Messages received by sessions are dispatched on the goroutine using the context:
+// inside interrupts while javascript running,in the pause loop while javascript paused by debugger,
+// or by DispatchMessages while context idle.
+type Inspector struct {
+	id   int
+	name string
+	ctx  *Context
+
+	locker sync.Mutex
+	tasks  []func()
+	closed bool
+	wake   chan struct{}
+
+	// Following fields are used by goroutine using the context only.
+	sessions     map[int]*InspectorSession
+	paused       bool
+	waiting      bool
+	breakOnStart bool
+	// hold native locker held by top level call paused on start.
+	hold unsafe.Pointer
+}
+
+// NewInspector creates inspector of context with given name shown by DevTools,
+// or returns inspector created already.
+// Inspector keeps inspecting context after Reset,and is closed when context closed.
+func (c *Context) NewInspector(name string) *Inspector {
+	if c.inspector != nil {
+		return c.inspector
+	}
+	i := &Inspector{
+		id:       nextInspectorID(),
+		name:     name,
+		ctx:      c,
+		wake:     make(chan struct{}, 1),
+		sessions: map[int]*InspectorSession{},
+	}
+	inspectorsLocker.Lock()
+	inspectors[i.id] = i
+	inspectorsLocker.Unlock()
+	c.inspector = i
+	i.contextCreated()
+	return i
+}
+
+// Inspector returns inspector of context,or nil if not created.
+func (c *Context) Inspector() *Inspector {
+	return c.inspector
+}
+
+// Name returns name of inspector.
+func (i *Inspector) Name() string {
+	return i.name
+}
+
+func (i *Inspector) contextCreated() {
+	name := utf16.Encode([]rune(i.name))
+	C.V8jsInspectorContextCreated(i.ctx.nativeContext(), C.int(i.id), utf16Ptr(name), C.size_t(len(name)))
+}
+
+func (i *Inspector) native() C.V8jsIsolatePtr {
+	return C.V8jsIsolatePtr(nativePtr(i.ctx.Raw.Isolate()))
+}
+
+// schedule queues task to run on goroutine using the context,
+// and interrupts running javascript to run it.
+func (i *Inspector) schedule(task func()) {
+	i.locker.Lock()
+	if i.closed {
+		i.locker.Unlock()
+		return
+	}
+	i.tasks = append(i.tasks, task)
+	i.locker.Unlock()
+	select {
+	case i.wake <- struct{}{}:
+	default:
+	}
+	iso := i.ctx.isolate
+	iso.locker.Lock()
+	defer iso.locker.Unlock()
+	if iso.Raw != nil {
+		C.V8jsInspectorRequestInterrupt(C.V8jsIsolatePtr(nativePtr(iso.Raw)), C.int(i.id))
+	}
+}
+
+// DispatchMessages dispatches messages received by sessions,and returns count of dispatched messages.
+// It should be called by goroutine using the context when context idle,
+// messages are dispatched automatically while javascript running or paused.
+func (i *Inspector) DispatchMessages() int {
+	count := 0
+	for {
+		i.locker.Lock()
+		tasks := i.tasks
+		i.tasks = nil
+		i.locker.Unlock()
+		if len(tasks) == 0 {
+			return count
+		}
+		for _, task := range tasks {
+			task()
+		}
+		count += len(tasks)
+	}
+}
+
+// loop dispatches messages until done returns true or inspector closed.
+func (i *Inspector) loop(done func() bool) {
+	for !done() && !i.isClosed() {
+		if i.DispatchMessages() == 0 {
+			<-i.wake
+		}
+	}
+}
+
+func (i *Inspector) isClosed() bool {
+	i.locker.Lock()
+	defer i.locker.Unlock()
+	return i.closed
+}
+
+// WaitForDebugger blocks until a session sends Runtime.runIfWaitingForDebugger,
+// then pauses next top level call into javascript,so debugger can set breakpoints before scripts run.
+func (i *Inspector) WaitForDebugger() {
+	i.waiting = true
+	i.loop(func() bool { return !i.waiting })
+	i.breakOnStart = !i.isClosed()
+}
+
+// enter dispatches pending messages before a top level call into javascript,
+// as interrupts requested while context idle are dropped by v8.
+//
+// Pause requested by WaitForDebugger is scheduled here too.
+// V8 drops scheduled pauses when its top level locker released,
+// so isolate is locked on current thread until the top level call finished.
+func (i *Inspector) enter() {
+	i.DispatchMessages()
+	if i.breakOnStart && len(i.sessions) > 0 {
+		i.breakOnStart = false
+		runtime.LockOSThread()
+		i.hold = C.V8jsIsolateLock(i.native())
+		for id := range i.sessions {
+			C.V8jsInspectorPauseOnNextStatement(i.native(), C.int(id))
+		}
+	}
+}
+
+// exit releases isolate locked by enter when top level call finished.
+func (i *Inspector) exit() {
+	if i.hold != nil {
+		C.V8jsIsolateUnlock(i.hold)
+		i.hold = nil
+		runtime.UnlockOSThread()
+	}
+}
+
+// Connect connects new session to inspector.
+// It is safe to call Connect from any goroutine.
+// Protocol messages sent by inspector are passed to send on goroutine using the context,
+// send should not block.
+func (i *Inspector) Connect(send func(message string)) *InspectorSession {
+	s := &InspectorSession{
+		id:        nextInspectorID(),
+		inspector: i,
+		send:      send,
+		done:      make(chan struct{}),
+	}
+	i.schedule(func() {
+		inspectorsLocker.Lock()
+		inspectorSessions[s.id] = s
+		inspectorsLocker.Unlock()
+		i.sessions[s.id] = s
+		C.V8jsInspectorConnect(i.native(), C.int(i.id), C.int(s.id))
+	})
+	return s
+}
+
+// Close disconnects all sessions and stops inspecting context.
+// Javascript paused by debugger is resumed.
+func (i *Inspector) Close() {
+	i.locker.Lock()
+	if i.closed {
+		i.locker.Unlock()
+		return
+	}
+	i.closed = true
+	i.tasks = nil
+	i.locker.Unlock()
+	for _, s := range i.sessions {
+		s.disconnect()
+	}
+	i.paused = false
+	i.waiting = false
+	C.V8jsInspectorContextDestroyed(i.ctx.nativeContext())
+	inspectorsLocker.Lock()
+	delete(inspectors, i.id)
+	inspectorsLocker.Unlock()
+	i.ctx.inspector = nil
+}
+
+// InspectorSession session of inspector,usually a DevTools connection.
+type InspectorSession struct {
+	id        int
+	inspector *Inspector
+	send      func(message string)
+	done      chan struct{}
+	once      sync.Once
+}
+
+// Dispatch sends protocol message to inspector.
+// It is safe to call Dispatch from any goroutine.
+func (s *InspectorSession) Dispatch(message string) {
+	data := utf16.Encode([]rune(message))
+	s.inspector.schedule(func() {
+		C.V8jsInspectorDispatch(s.inspector.native(), C.int(s.id), utf16Ptr(data), C.size_t(len(data)))
+	})
+}
+
+// Disconnect disconnects session,javascript paused by session is resumed.
+// It is safe to call Disconnect from any goroutine.
+func (s *InspectorSession) Disconnect() {
+	s.inspector.schedule(s.disconnect)
+}
+
+// Done returns a channel closed when session disconnected,by Disconnect or inspector closed.
+func (s *InspectorSession) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *InspectorSession) disconnect() {
+	i := s.inspector
+	if i.sessions[s.id] == nil {
+		return
+	}
+	delete(i.sessions, s.id)
+	C.V8jsInspectorDisconnect(i.native(), C.int(s.id))
+	inspectorsLocker.Lock()
+	delete(inspectorSessions, s.id)
+	inspectorsLocker.Unlock()
+	s.once.Do(func() { close(s.done) })
+}
+
+func utf16Ptr(data []uint16) *C.uint16_t {
+	if len(data) == 0 {
+		return nil
+	}
+	return (*C.uint16_t)(unsafe.Pointer(&data[0]))
+}
+
+//export v8jsInspectorSend
+func v8jsInspectorSend(ref C.int, is8bit C.int, data unsafe.Pointer, length C.size_t) {
+	s := inspectorSessionByID(int(ref))
+	if s == nil || s.send == nil {
+		return
+	}
+	var message string
+	if is8bit != 0 {
+		// 8 bit strings of inspector are latin1.
+		chars := unsafe.Slice((*byte)(data), int(length))
+		runes := make([]rune, len(chars))
+		for k, c := range chars {
+			runes[k] = rune(c)
+		}
+		message = string(runes)
+	} else {
+		message = string(utf16.Decode(unsafe.Slice((*uint16)(data), int(length))))
+	}
+	s.send(message)
+}
+
+//export v8jsInspectorPause
+func v8jsInspectorPause(group C.int) {
+	i := inspectorByID(int(group))
+	if i == nil {
+		return
+	}
+	i.paused = true
+	i.loop(func() bool { return !i.paused })
+}
+
+//export v8jsInspectorQuitPause
+func v8jsInspectorQuitPause(group C.int) {
+	if i := inspectorByID(int(group)); i != nil {
+		i.paused = false
+	}
+}
+
+//export v8jsInspectorRunIfWaiting
+func v8jsInspectorRunIfWaiting(group C.int) {
+	if i := inspectorByID(int(group)); i != nil {
+		i.waiting = false
+	}
+}
+
+//export v8jsInspectorInterrupt
+func v8jsInspectorInterrupt(group C.int) {
+	if i := inspectorByID(int(group)); i != nil {
+		i.DispatchMessages()
+	}
+}
@@ -0,0 +1,274 @@
+// Package inspector serves inspectors of v8js contexts to Chrome DevTools.
+//
+// Server implements DevTools HTTP discovery endpoints /json,/json/list and /json/version,
+// and websocket debugger connections of Chrome DevTools protocol.
+// Targets can be opened by chrome://inspect,or by urls returned by Server.DevToolsURL.
+//
+// Inspectors allow to run any code in inspected contexts,
+// so server should listen on loopback address only.
+package inspector
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/herb-go/v8go"
+	"github.com/jarlyyn/v8js"
+)
+
+// DefaultAddr default address of inspector server,same as node.
+const DefaultAddr = "127.0.0.1:9229"
+
+var ErrServerStarted = errors.New("inspector: server started")
+
+type target struct {
+	id        string
+	title     string
+	inspector *v8js.Inspector
+	conns     map[*conn]*v8js.InspectorSession
+}
+
+// Server inspector server.
+type Server struct {
+	Addr string
+
+	locker   sync.Mutex
+	listener net.Listener
+	server   *http.Server
+	targets  map[string]*target
+	order    []string
+}
+
+// NewServer creates inspector server listening on given address.
+// DefaultAddr used if addr is empty,use port 0 to listen on a random port.
+func NewServer(addr string) *Server {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	return &Server{
+		Addr:    addr,
+		targets: map[string]*target{},
+	}
+}
+
+// Start starts listening and serving in background.
+func (s *Server) Start() error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	if s.listener != nil {
+		return ErrServerStarted
+	}
+	l, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	s.listener = l
+	s.server = &http.Server{Handler: s}
+	go s.server.Serve(l)
+	return nil
+}
+
+// Address returns address server listening on,or Addr if server not started.
+func (s *Server) Address() string {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	if s.listener == nil {
+		return s.Addr
+	}
+	return s.listener.Addr().String()
+}
+
+// Register registers inspector as DevTools target with given title,and returns target id.
+func (s *Server) Register(title string, i *v8js.Inspector) string {
+	id := newID()
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	s.targets[id] = &target{
+		id:        id,
+		title:     title,
+		inspector: i,
+		conns:     map[*conn]*v8js.InspectorSession{},
+	}
+	s.order = append(s.order, id)
+	return id
+}
+
+// Unregister unregisters target with given id,and disconnects its DevTools connections.
+func (s *Server) Unregister(id string) {
+	s.locker.Lock()
+	t := s.targets[id]
+	delete(s.targets, id)
+	for k, v := range s.order {
+		if v == id {
+			s.order = append(s.order[:k], s.order[k+1:]...)
+			break
+		}
+	}
+	if t != nil {
+		t.close()
+	}
+	s.locker.Unlock()
+}
+
+// Close stops server and disconnects all DevTools connections.
+func (s *Server) Close() error {
+	s.locker.Lock()
+	for _, t := range s.targets {
+		t.close()
+	}
+	s.targets = map[string]*target{}
+	s.order = nil
+	server := s.server
+	s.server = nil
+	s.listener = nil
+	s.locker.Unlock()
+	if server == nil {
+		return nil
+	}
+	return server.Close()
+}
+
+// WebSocketURL returns websocket debugger url of target with given id.
+func (s *Server) WebSocketURL(id string) string {
+	return "ws://" + s.Address() + "/" + id
+}
+
+// DevToolsURL returns url which opens target with given id in Chrome DevTools.
+func (s *Server) DevToolsURL(id string) string {
+	return devToolsURL(s.Address(), id)
+}
+
+func devToolsURL(host string, id string) string {
+	return "devtools://devtools/bundled/js_app.html?experiments=true&v8only=true&ws=" + host + "/" + id
+}
+
+// close closes connections of target,server locker should be held.
+func (t *target) close() {
+	for c := range t.conns {
+		c.Close()
+	}
+}
+
+func newID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// allowedHost reports whether host header is an ip address or localhost,
+// to prevent dns rebinding attacks from web pages.
+func allowedHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return net.ParseIP(host) != nil || strings.EqualFold(host, "localhost")
+}
+
+type targetInfo struct {
+	Description          string `json:"description"`
+	DevtoolsFrontendURL  string `json:"devtoolsFrontendUrl"`
+	FaviconURL           string `json:"faviconUrl"`
+	ID                   string `json:"id"`
+	Title                string `json:"title"`
+	Type                 string `json:"type"`
+	URL                  string `json:"url"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Write(data)
+}
+
+// ServeHTTP serves discovery endpoints and websocket connections.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowedHost(r.Host) {
+		http.Error(w, "host not allowed", http.StatusForbidden)
+		return
+	}
+	switch r.URL.Path {
+	case "/json", "/json/list":
+		s.locker.Lock()
+		list := make([]*targetInfo, 0, len(s.order))
+		for _, id := range s.order {
+			t := s.targets[id]
+			list = append(list, &targetInfo{
+				Description:          "v8js instance",
+				DevtoolsFrontendURL:  devToolsURL(r.Host, id),
+				ID:                   id,
+				Title:                t.title,
+				Type:                 "node",
+				URL:                  "file://",
+				WebSocketDebuggerURL: "ws://" + r.Host + "/" + id,
+			})
+		}
+		s.locker.Unlock()
+		writeJSON(w, list)
+	case "/json/version":
+		writeJSON(w, map[string]string{
+			"Browser":          "v8js/" + v8go.Version(),
+			"Protocol-Version": "1.1",
+		})
+	default:
+		s.serveTarget(w, r, strings.TrimPrefix(r.URL.Path, "/"))
+	}
+}
+
+// serveTarget connects websocket connection to inspector of target.
+func (s *Server) serveTarget(w http.ResponseWriter, r *http.Request, id string) {
+	s.locker.Lock()
+	t := s.targets[id]
+	s.locker.Unlock()
+	if t == nil {
+		http.NotFound(w, r)
+		return
+	}
+	c, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+	session := t.inspector.Connect(c.WriteMessage)
+	s.locker.Lock()
+	if s.targets[id] != t {
+		s.locker.Unlock()
+		c.Close()
+		session.Disconnect()
+		return
+	}
+	t.conns[c] = session
+	s.locker.Unlock()
+	go func() {
+		select {
+		case <-session.Done():
+			c.Close()
+		case <-c.done:
+		}
+	}()
+	for {
+		message, err := c.ReadMessage()
+		if err != nil {
+			break
+		}
+		session.Dispatch(message)
+	}
+	c.Close()
+	session.Disconnect()
+	s.locker.Lock()
+	delete(t.conns, c)
+	s.locker.Unlock()
+}
@@ -0,0 +1,176 @@
+package inspector
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarlyyn/v8js"
+)
+
+type testClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dial(t *testing.T, url string) *testClient {
+	addr := strings.TrimPrefix(url, "ws://")
+	path := addr[strings.Index(addr, "/"):]
+	addr = addr[:strings.Index(addr, "/")]
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key [16]byte
+	rand.Read(key[:])
+	conn.Write([]byte("GET " + path + " HTTP/1.1\r\nHost: " + addr + "\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + base64.StdEncoding.EncodeToString(key[:]) + "\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatal(resp.Status)
+	}
+	return &testClient{conn: conn, reader: reader}
+}
+
+func (c *testClient) send(message string) {
+	payload := []byte(message)
+	frame := []byte{0x81}
+	if len(payload) < 126 {
+		frame = append(frame, 0x80|byte(len(payload)))
+	} else {
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	}
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+	for k, b := range payload {
+		frame = append(frame, b^mask[k%4])
+	}
+	c.conn.Write(frame)
+}
+
+func (c *testClient) read() (string, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return "", err
+	}
+	length := int(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		io.ReadFull(c.reader, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		io.ReadFull(c.reader, ext[:])
+		length = int(binary.BigEndian.Uint64(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+func TestServer(t *testing.T) {
+	ctx := v8js.NewContext()
+	defer ctx.Close()
+	s := NewServer("127.0.0.1:0")
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if s.Start() != ErrServerStarted {
+		t.Fatal()
+	}
+	id := s.Register("test plugin", ctx.NewInspector("test"))
+	if !strings.HasSuffix(s.DevToolsURL(id), "ws="+s.Address()+"/"+id) {
+		t.Fatal(s.DevToolsURL(id))
+	}
+	resp, err := http.Get("http://" + s.Address() + "/json/list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list []*targetInfo
+	err = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if err != nil || len(list) != 1 || list[0].ID != id || list[0].Title != "test plugin" || list[0].WebSocketDebuggerURL != s.WebSocketURL(id) {
+		t.Fatal(err, list)
+	}
+	req, _ := http.NewRequest("GET", "http://"+s.Address()+"/json/version", nil)
+	req.Host = "attacker.example:9229"
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatal(resp.Status)
+	}
+
+	c := dial(t, s.WebSocketURL(id))
+	defer c.conn.Close()
+	c.send(`{"id":1,"method":"Runtime.evaluate","params":{"expression":"'` + strings.Repeat("a", 200) + `'.length"}}`)
+	received := make(chan string)
+	go func() {
+		for {
+			message, err := c.read()
+			if err != nil {
+				close(received)
+				return
+			}
+			received <- message
+		}
+	}()
+	var message string
+	deadline := time.After(5 * time.Second)
+	for message == "" {
+		ctx.Inspector().DispatchMessages()
+		select {
+		case message = <-received:
+		case <-deadline:
+			t.Fatal("timeout")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if !strings.Contains(message, `"id":1`) || !strings.Contains(message, `"value":200`) {
+		t.Fatal(message)
+	}
+	s.Unregister(id)
+	select {
+	case _, ok := <-received:
+		if ok {
+			t.Fatal()
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+	ctx.Inspector().DispatchMessages()
+}
+
+func TestAllowedHost(t *testing.T) {
+	for host, allowed := range map[string]bool{
+		"127.0.0.1:9229":   true,
+		"[::1]:9229":       true,
+		"localhost:9229":   true,
+		"LOCALHOST":        true,
+		"example.com":      false,
+		"127.0.0.1.nip.io": false,
+	} {
+		if allowedHost(host) != allowed {
+			t.Fatal(host)
+		}
+	}
+}
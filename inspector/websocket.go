@@ -0,0 +1,260 @@
+package inspector
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// MaxMessageSize max size of message received from DevTools.
+const MaxMessageSize = 64 * 1024 * 1024
+
+var ErrMessageTooLarge = errors.New("inspector: websocket message too large")
+var ErrInvalidFrame = errors.New("inspector: invalid websocket frame")
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// conn minimal server side websocket connection of RFC 6455,
+// which is enough for Chrome DevTools protocol.
+type conn struct {
+	raw    net.Conn
+	reader *bufio.Reader
+
+	locker  sync.Mutex
+	queue   [][]byte
+	closed  bool
+	pending chan struct{}
+	done    chan struct{}
+}
+
+// upgrade upgrades http request to websocket connection.
+func upgrade(w http.ResponseWriter, r *http.Request) (*conn, error) {
+	if r.Method != http.MethodGet ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-Websocket-Version") != "13" {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return nil, ErrInvalidFrame
+	}
+	key := r.Header.Get("Sec-Websocket-Key")
+	if key == "" {
+		http.Error(w, "websocket key required", http.StatusBadRequest)
+		return nil, ErrInvalidFrame
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, http.ErrNotSupported
+	}
+	raw, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	_, err = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if err == nil {
+		err = rw.Flush()
+	}
+	if err != nil {
+		raw.Close()
+		return nil, err
+	}
+	c := &conn{
+		raw:     raw,
+		reader:  rw.Reader,
+		pending: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go c.writeLoop()
+	return c, nil
+}
+
+func headerContains(h http.Header, name string, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReadMessage reads next text or binary message,control frames are handled while reading.
+func (c *conn) ReadMessage() (string, error) {
+	var message []byte
+	started := false
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return "", err
+		}
+		switch op {
+		case opPing:
+			c.send(opPong, payload)
+			continue
+		case opPong:
+			continue
+		case opClose:
+			c.send(opClose, payload)
+			return "", io.EOF
+		case opText, opBinary:
+			if started {
+				return "", ErrInvalidFrame
+			}
+			started = true
+		case opContinuation:
+			if !started {
+				return "", ErrInvalidFrame
+			}
+		default:
+			return "", ErrInvalidFrame
+		}
+		if len(message)+len(payload) > MaxMessageSize {
+			return "", ErrMessageTooLarge
+		}
+		message = append(message, payload...)
+		if fin {
+			if !utf8.Valid(message) {
+				return "", ErrInvalidFrame
+			}
+			return string(message), nil
+		}
+	}
+}
+
+func (c *conn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.reader, header[:]); err != nil {
+		return
+	}
+	fin = header[0]&0x80 != 0
+	op = header[0] & 0x0f
+	// frames sent by client must be masked.
+	if header[0]&0x70 != 0 || header[1]&0x80 == 0 {
+		err = ErrInvalidFrame
+		return
+	}
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.reader, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.reader, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if op >= opClose && (length > 125 || !fin) {
+		err = ErrInvalidFrame
+		return
+	}
+	if length > MaxMessageSize {
+		err = ErrMessageTooLarge
+		return
+	}
+	var mask [4]byte
+	if _, err = io.ReadFull(c.reader, mask[:]); err != nil {
+		return
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.reader, payload); err != nil {
+		return
+	}
+	for k := range payload {
+		payload[k] ^= mask[k%4]
+	}
+	return
+}
+
+// WriteMessage queues text message,it never blocks.
+func (c *conn) WriteMessage(message string) {
+	c.send(opText, []byte(message))
+}
+
+func (c *conn) send(op byte, payload []byte) {
+	frame := make([]byte, 0, len(payload)+10)
+	frame = append(frame, 0x80|op)
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, 126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	default:
+		frame = append(frame, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(len(payload)))
+	}
+	frame = append(frame, payload...)
+	c.locker.Lock()
+	if c.closed {
+		c.locker.Unlock()
+		return
+	}
+	c.queue = append(c.queue, frame)
+	c.locker.Unlock()
+	select {
+	case c.pending <- struct{}{}:
+	default:
+	}
+}
+
+func (c *conn) writeLoop() {
+	defer c.raw.Close()
+	for {
+		select {
+		case <-c.pending:
+		case <-c.done:
+			return
+		}
+		c.locker.Lock()
+		queue := c.queue
+		c.queue = nil
+		c.locker.Unlock()
+		for _, frame := range queue {
+			if _, err := c.raw.Write(frame); err != nil {
+				c.Close()
+				return
+			}
+		}
+	}
+}
+
+// Close closes connection,queued messages are dropped.
+func (c *conn) Close() {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	c.queue = nil
+	close(c.done)
+	// unblocks reader,writer closes connection when stopped.
+	c.raw.Close()
+}
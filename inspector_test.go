@@ -0,0 +1,167 @@
+package v8js
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type inspectorMessage struct {
+	ID     int                    `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+	Result map[string]interface{} `json:"result"`
+}
+
+func parseInspectorMessage(t *testing.T, data string) *inspectorMessage {
+	msg := &inspectorMessage{}
+	if err := json.Unmarshal([]byte(data), msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestInspectorEvaluate(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	i := ctx.NewInspector("test")
+	if ctx.NewInspector("other") != i || ctx.Inspector() != i {
+		t.Fatal()
+	}
+	var messages []*inspectorMessage
+	s := i.Connect(func(message string) {
+		messages = append(messages, parseInspectorMessage(t, message))
+	})
+	s.Dispatch(`{"id":1,"method":"Runtime.evaluate","params":{"expression":"'中文' + (1 + 1)"}}`)
+	if i.DispatchMessages() != 2 {
+		t.Fatal()
+	}
+	if len(messages) != 1 || messages[0].ID != 1 {
+		t.Fatal(messages)
+	}
+	result := messages[0].Result["result"].(map[string]interface{})
+	if result["value"] != "中文2" {
+		t.Fatal(result)
+	}
+	messages = nil
+	s.Dispatch(`{"id":2,"method":"Runtime.enable"}`)
+	i.DispatchMessages()
+	var created *inspectorMessage
+	for _, msg := range messages {
+		if msg.Method == "Runtime.executionContextCreated" {
+			created = msg
+		}
+	}
+	if created == nil || created.Params["context"].(map[string]interface{})["name"] != "test" {
+		t.Fatal(messages)
+	}
+	ctx.Reset()
+	messages = nil
+	s.Dispatch(`{"id":3,"method":"Runtime.evaluate","params":{"expression":"typeof globalThis"}}`)
+	i.DispatchMessages()
+	if len(messages) == 0 || messages[len(messages)-1].Result["result"].(map[string]interface{})["value"] != "object" {
+		t.Fatal(messages)
+	}
+	i.Close()
+	select {
+	case <-s.Done():
+	default:
+		t.Fatal()
+	}
+	if ctx.Inspector() != nil {
+		t.Fatal()
+	}
+}
+
+func TestInspectorPause(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	i := ctx.NewInspector("test")
+	var s *InspectorSession
+	var paused *inspectorMessage
+	var value interface{}
+	s = i.Connect(func(message string) {
+		msg := parseInspectorMessage(t, message)
+		switch {
+		case msg.Method == "Debugger.paused":
+			paused = msg
+			frame := msg.Params["callFrames"].([]interface{})[0].(map[string]interface{})
+			s.Dispatch(`{"id":2,"method":"Debugger.evaluateOnCallFrame","params":{"callFrameId":"` + frame["callFrameId"].(string) + `","expression":"a * 2"}}`)
+		case msg.ID == 2:
+			value = msg.Result["result"].(map[string]interface{})["value"]
+			s.Dispatch(`{"id":3,"method":"Debugger.resume"}`)
+		}
+	})
+	s.Dispatch(`{"id":1,"method":"Debugger.enable"}`)
+	i.DispatchMessages()
+	result := ctx.RunScript(`(function() {
+	var a = 21;
+	debugger;
+	return a;
+})()`, "pause.js")
+	defer result.Release()
+	if result.Integer() != 21 || paused == nil || value != float64(42) {
+		t.Fatal(paused, value)
+	}
+	if paused.Params["reason"] != "other" {
+		t.Fatal(paused.Params)
+	}
+}
+
+func TestInspectorWaitForDebugger(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	i := ctx.NewInspector("test")
+	var reason interface{}
+	var s *InspectorSession
+	s = i.Connect(func(message string) {
+		msg := parseInspectorMessage(t, message)
+		if msg.Method == "Debugger.paused" {
+			reason = msg.Params["reason"]
+			s.Dispatch(`{"id":3,"method":"Debugger.resume"}`)
+		}
+	})
+	go func() {
+		s.Dispatch(`{"id":1,"method":"Debugger.enable"}`)
+		s.Dispatch(`{"id":2,"method":"Runtime.runIfWaitingForDebugger"}`)
+	}()
+	i.WaitForDebugger()
+	result := ctx.RunScript(`(function() { return 1 + 1; })()`, "main.js")
+	defer result.Release()
+	if result.Integer() != 2 || reason != "Break on start" {
+		t.Fatal(reason)
+	}
+	s.Disconnect()
+	i.DispatchMessages()
+	select {
+	case <-s.Done():
+	default:
+		t.Fatal()
+	}
+}
+
+func TestInspectorInterrupt(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	i := ctx.NewInspector("test")
+	done := make(chan string, 1)
+	var s *InspectorSession
+	s = i.Connect(func(message string) {
+		msg := parseInspectorMessage(t, message)
+		if msg.ID == 1 {
+			s.Dispatch(`{"id":2,"method":"Runtime.evaluate","params":{"expression":"stop = true"}}`)
+		}
+		if msg.ID == 2 {
+			done <- message
+		}
+	})
+	i.DispatchMessages()
+	go s.Dispatch(`{"id":1,"method":"Runtime.enable"}`)
+	result := ctx.RunScript(`var stop = false;
+while (!stop) {}
+'stopped'`, "loop.js")
+	defer result.Release()
+	if result.String() != "stopped" || !strings.Contains(<-done, `"value":true`) {
+		t.Fatal()
+	}
+}
@@ -0,0 +1,107 @@
+package v8js
+
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import (
+	"strconv"
+	"unsafe"
+)
+
+// currentStackTraceLimit max frames captured by CurrentStackTrace.
+const currentStackTraceLimit = 64
+
+// RequestInterrupt schedules fn to run on the thread running javascript in isolate of context,
+// as soon as javascript reaches an interrupt check of v8,such as loop back edges and function entries.
+// Pure javascript loops are interrupted too.
+// If no javascript is running,fn runs before next top level call into javascript made by Go,
+// such as RunScript,Call and PerformMicrotaskCheckpoint.
+// It is safe to call RequestInterrupt from any goroutine.
+//
+// fn must not call into javascript or create values,as v8 does not allow interrupts to reenter the isolate.
+// CurrentStackTrace and TerminateExecution can be used.
+//
+// Panic in fn terminates running javascript,
+// and the panicked error is returned to the top level Go caller instead of the termination error.
+// Panic in fn run before a top level call is panicked by the call directly.
+func (c *Context) RequestInterrupt(fn func(ctx *Context)) {
+	c.interruptLocker.Lock()
+	c.interrupts = append(c.interrupts, fn)
+	c.interruptLocker.Unlock()
+	i := c.isolate
+	i.locker.Lock()
+	defer i.locker.Unlock()
+	if i.Raw != nil {
+		C.V8jsRequestInterrupt(C.V8jsIsolatePtr(nativePtr(i.Raw)), C.int(contextRef(c.Raw)))
+	}
+}
+
+//export v8jsInterrupt
+func v8jsInterrupt(ref C.int) {
+	c := contextByRef(int(ref))
+	if c == nil || c.Raw == nil {
+		return
+	}
+	if err := c.runInterrupts(); err != nil {
+		c.isolate.interruptErr = err
+		c.Raw.Isolate().TerminateExecution()
+	}
+}
+
+// runInterrupts runs pending interrupts requested by RequestInterrupt.
+// Return error panicked by interrupt.
+func (c *Context) runInterrupts() (err error) {
+	c.interruptLocker.Lock()
+	interrupts := c.interrupts
+	c.interrupts = nil
+	c.interruptLocker.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+	for _, fn := range interrupts {
+		fn(c)
+	}
+	return nil
+}
+
+// TerminateExecution terminates javascript running in isolate of context,
+// uncaught termination error will be returned to Go caller.
+// It is safe to call TerminateExecution from any goroutine.
+func (c *Context) TerminateExecution() {
+	i := c.isolate
+	i.locker.Lock()
+	defer i.locker.Unlock()
+	if i.Raw != nil {
+		i.Raw.TerminateExecution()
+	}
+}
+
+// CurrentStackTrace returns stack trace of running javascript,
+// mapped by source maps if enabled.
+// Returns nil if no javascript running,
+// usually called inside Go callbacks or interrupts.
+func (c *Context) CurrentStackTrace() []*StackFrame {
+	trace := C.V8jsCurrentStackTrace(C.V8jsIsolatePtr(nativePtr(c.Raw.Isolate())), currentStackTraceLimit)
+	if trace.length == 0 {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(trace.frames))
+	locations := unsafe.Slice(trace.frames, int(trace.length))
+	frames := make([]*StackFrame, len(locations))
+	for k, loc := range locations {
+		frames[k] = &StackFrame{
+			Function: C.GoString(loc.name),
+			Script:   C.GoString(loc.resource),
+			Line:     int(loc.line),
+			Column:   int(loc.column),
+		}
+		C.free(unsafe.Pointer(loc.name))
+		C.free(unsafe.Pointer(loc.resource))
+		if mapped := c.mapLocation(frames[k].Script + ":" + strconv.Itoa(frames[k].Line) + ":" + strconv.Itoa(frames[k].Column)); mapped != "" {
+			parseStackLocation(frames[k], mapped)
+		}
+	}
+	return frames
+}
@@ -0,0 +1,83 @@
+package v8js
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInterrupt(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	var frames []*StackFrame
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ctx.RequestInterrupt(func(ctx *Context) {
+			frames = ctx.CurrentStackTrace()
+			panic(errors.New("cancelled"))
+		})
+	}()
+	var recovered interface{}
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		ctx.RunScript(`function work() {
+	for (;;) {}
+}
+work()`, "work.js")
+	}()
+	if err, ok := recovered.(error); !ok || err.Error() != "cancelled" {
+		t.Fatal(recovered)
+	}
+	if len(frames) != 2 || frames[0].Function != "work" || frames[0].Line != 2 || frames[1].String() != "work.js:4:1" {
+		t.Fatal(frames)
+	}
+	if ctx.CurrentStackTrace() != nil {
+		t.Fatal()
+	}
+	result := ctx.RunScript(`1 + 1`, "main.js")
+	defer result.Release()
+	if result.Integer() != 2 {
+		t.Fatal(result.Integer())
+	}
+	called := false
+	ctx.RequestInterrupt(func(ctx *Context) {
+		called = true
+	})
+	ctx.RunScript(`(function() {})()`, "main.js").Release()
+	if !called {
+		t.Fatal()
+	}
+	ctx.RequestInterrupt(func(ctx *Context) {
+		panic(errors.New("cancelled"))
+	})
+	defer func() {
+		if err, ok := recover().(error); !ok || err.Error() != "cancelled" {
+			t.Fatal(err)
+		}
+	}()
+	ctx.RunScript(`1`, "main.js")
+}
+
+func TestTerminateExecution(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ctx.TerminateExecution()
+	}()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal()
+			}
+		}()
+		ctx.RunScript(`for (;;) {}`, "loop.js")
+	}()
+	result := ctx.RunScript(`1 + 1`, "main.js")
+	defer result.Release()
+	if result.Integer() != 2 {
+		t.Fatal(result.Integer())
+	}
+}
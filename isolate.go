@@ -0,0 +1,180 @@
+package v8js
+
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/herb-go/v8go"
+)
+
+var ErrIsolateDisposed = errors.New("v8js: isolate disposed")
+var ErrDifferentIsolate = errors.New("v8js: contexts belong to different isolates")
+var ErrSecurityTokenMismatch = errors.New("v8js: security token mismatch")
+
+// Isolate javascript engine instance which can host many contexts.
+// Contexts share heap of isolate,and are much cheaper than contexts created by NewContext.
+//
+// Contexts of same isolate must not be used concurrently.
+type Isolate struct {
+	locker   sync.Mutex
+	Raw      *v8go.Isolate
+	owner    *Context
+	contexts []*Context
+	// ownerClosed is set when owner closed while other contexts alive,
+	// isolate is disposed when last context closed.
+	ownerClosed bool
+
+	stackLimit int
+	stackSize  int
+	// stackHold native locker held by top level call while stack size set.
+	stackHold unsafe.Pointer
+	depth     atomic.Int32
+	// overflowed is set when reentrancy limit reached,until top level call finished.
+	overflowed atomic.Bool
+	// interruptErr error panicked by interrupt,returned to top level caller.
+	interruptErr error
+}
+
+// NewIsolate creates new isolate.
+// Isolate should be disposed by Dispose after used.
+func NewIsolate() *Isolate {
+	startEngine()
+	return &Isolate{
+		Raw: v8go.NewIsolate(),
+	}
+}
+
+// NewContext creates new context in isolate.
+func (i *Isolate) NewContext(opt ...v8go.ContextOption) *Context {
+	i.locker.Lock()
+	raw := i.Raw
+	i.locker.Unlock()
+	if raw == nil {
+		panic(ErrIsolateDisposed)
+	}
+	return newContext(i, v8go.NewContext(append(opt, raw)...), opt)
+}
+
+// Contexts returns contexts created in isolate and not closed yet.
+func (i *Isolate) Contexts() []*Context {
+	i.locker.Lock()
+	defer i.locker.Unlock()
+	return append([]*Context{}, i.contexts...)
+}
+
+func (i *Isolate) add(c *Context) {
+	i.locker.Lock()
+	defer i.locker.Unlock()
+	i.contexts = append(i.contexts, c)
+}
+
+// remove removes closed context from isolate,
+// and disposes isolate if it is owned by closed contexts and no context left.
+func (i *Isolate) remove(c *Context) {
+	i.locker.Lock()
+	defer i.locker.Unlock()
+	for k := range i.contexts {
+		if i.contexts[k] == c {
+			i.contexts = append(i.contexts[:k], i.contexts[k+1:]...)
+			break
+		}
+	}
+	if i.owner == c {
+		i.ownerClosed = true
+	}
+	if i.ownerClosed && len(i.contexts) == 0 {
+		i.dispose()
+	}
+}
+
+func (i *Isolate) dispose() {
+	if i.Raw != nil {
+		C.V8jsIsolateDispose(C.V8jsIsolatePtr(nativePtr(i.Raw)))
+		i.Raw.Dispose()
+		i.Raw = nil
+	}
+}
+
+// Dispose closes all contexts of isolate,then disposes isolate.
+func (i *Isolate) Dispose() {
+	for _, c := range i.Contexts() {
+		c.Close()
+	}
+	i.locker.Lock()
+	defer i.locker.Unlock()
+	i.dispose()
+}
+
+// Isolate returns isolate of context.
+func (c *Context) Isolate() *Isolate {
+	return c.isolate
+}
+
+// SetSecurityToken sets security token of context,empty token restores default token of v8.
+// Values can only be transferred by TransferTo between contexts with same non-empty security token.
+// Token is set to v8 context too,so v8 denies access to global objects of contexts with different tokens.
+// Other transferred objects can still be accessed by any context of isolate,as v8 checks globals only.
+func (c *Context) SetSecurityToken(token string) {
+	c.securityToken = token
+	c.applySecurityToken()
+}
+
+func (c *Context) applySecurityToken() {
+	if c.securityToken == "" {
+		C.V8jsSetSecurityToken(c.nativeContext(), nil)
+		return
+	}
+	token := C.CString(c.securityToken)
+	defer C.free(unsafe.Pointer(token))
+	C.V8jsSetSecurityToken(c.nativeContext(), token)
+}
+
+// SecurityToken returns security token of context.
+func (c *Context) SecurityToken() string {
+	return c.securityToken
+}
+
+func (c *Context) checkSameIsolate(target *Context) error {
+	if c.isolate != target.isolate {
+		return ErrDifferentIsolate
+	}
+	return nil
+}
+
+// TransferTo returns value which refers to same javascript value in target context.
+// Target context must belong to same isolate and have same non-empty security token.
+// Transferred objects keep their original prototypes,functions run in context where they created.
+func (v *JsValue) TransferTo(target *Context) (*JsValue, error) {
+	if v.ctx != target {
+		if err := v.ctx.checkSameIsolate(target); err != nil {
+			return nil, err
+		}
+		if v.ctx.securityToken == "" || v.ctx.securityToken != target.securityToken {
+			return nil, ErrSecurityTokenMismatch
+		}
+	}
+	h := target.helper(transferHelper)
+	return h.Call(target.NullValue(), v.ConsumeReuseble().Consume()), nil
+}
+
+var transferHelper = registerHelper("transfer.js", `(function(v) { return v; })`)
+
+// CloneTo deeply clones value into target context,by serializing value with JsValue.Serialize and deserializing it in target context.
+// Cloned objects use builtin prototypes of target context and share nothing with source value.
+// Target context must belong to same isolate,security token is not required as nothing is shared.
+// Functions,symbols,promises,weak collections and host objects can not be cloned.
+func (v *JsValue) CloneTo(target *Context) (*JsValue, error) {
+	if err := v.ctx.checkSameIsolate(target); err != nil {
+		return nil, err
+	}
+	data, err := v.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return target.Deserialize(data)
+}
@@ -0,0 +1,152 @@
+package v8js
+
+import "testing"
+
+func TestIsolate(t *testing.T) {
+	iso := NewIsolate()
+	ctx1 := iso.NewContext()
+	ctx2 := iso.NewContext()
+	if len(iso.Contexts()) != 2 || ctx1.Isolate() != iso {
+		t.Fatal()
+	}
+	ctx1.RunScript(`globalThis.name = "ctx1"`, "main.js").Release()
+	name := ctx2.RunScript(`typeof globalThis.name`, "main.js")
+	if name.String() != "undefined" {
+		t.Fatal(name.String())
+	}
+	name.Release()
+	ctx2.Close()
+	if len(iso.Contexts()) != 1 {
+		t.Fatal()
+	}
+	name = ctx1.RunScript(`globalThis.name`, "main.js")
+	if name.String() != "ctx1" {
+		t.Fatal(name.String())
+	}
+	name.Release()
+	iso.Dispose()
+	if len(iso.Contexts()) != 0 || ctx1.Raw != nil || iso.Raw != nil {
+		t.Fatal()
+	}
+	defer func() {
+		if r := recover(); r != ErrIsolateDisposed {
+			t.Fatal(r)
+		}
+	}()
+	iso.NewContext()
+}
+
+func TestTransferAndClone(t *testing.T) {
+	iso := NewIsolate()
+	defer iso.Dispose()
+	ctx1 := iso.NewContext()
+	ctx2 := iso.NewContext()
+	other := NewContext()
+	defer other.Close()
+	obj := ctx1.RunScript(`({ list: [1, new Date(0), new Map([["k", /a/g]])], buf: new Uint8Array([1, 2, 3]), err: new RangeError("range") })`, "main.js")
+	defer obj.Release()
+	obj.Set("self", obj.ConsumeReuseble().Consume())
+	if _, err := obj.TransferTo(ctx2); err != ErrSecurityTokenMismatch {
+		t.Fatal(err)
+	}
+	if _, err := obj.TransferTo(other); err != ErrDifferentIsolate {
+		t.Fatal(err)
+	}
+	if _, err := obj.CloneTo(other); err != ErrDifferentIsolate {
+		t.Fatal(err)
+	}
+	ctx1.SetSecurityToken("tenant")
+	ctx2.SetSecurityToken("tenant")
+	transferred, err := obj.TransferTo(ctx2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer transferred.Release()
+	ctx2.Global().Set("transferred", transferred.ConsumeReuseble().Consume())
+	check := ctx2.RunScript(`transferred instanceof Object`, "main.js")
+	defer check.Release()
+	if check.Boolean() {
+		t.Fatal("transferred value should keep prototypes of source context")
+	}
+	cloned, err := obj.CloneTo(ctx2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx2.Global().Set("cloned", cloned.Consume())
+	result := ctx2.RunScript(`[
+		cloned instanceof Object,
+		cloned.self === cloned,
+		cloned.list[1] instanceof Date,
+		cloned.list[2].get("k") instanceof RegExp,
+		cloned.buf instanceof Uint8Array && cloned.buf.join(","),
+		cloned.err instanceof RangeError && cloned.err.message
+	].join(" ")`, "main.js")
+	defer result.Release()
+	if result.String() != "true true true true 1,2,3 range" {
+		t.Fatal(result.String())
+	}
+	fn := ctx1.RunScript(`(function() {})`, "main.js")
+	defer fn.Release()
+	if _, err := fn.CloneTo(ctx2); err == nil {
+		t.Fatal()
+	}
+}
+
+func TestIsolateOwnerClosed(t *testing.T) {
+	owner := NewContext()
+	iso := owner.Isolate()
+	sibling := iso.NewContext()
+	owner.Close()
+	if iso.Raw == nil || len(iso.Contexts()) != 1 {
+		t.Fatal()
+	}
+	result := sibling.RunScript(`1 + 1`, "main.js")
+	if result.Integer() != 2 {
+		t.Fatal(result.Integer())
+	}
+	result.Release()
+	sibling.Close()
+	if iso.Raw != nil {
+		t.Fatal()
+	}
+}
+
+func TestSecurityToken(t *testing.T) {
+	iso := NewIsolate()
+	defer iso.Dispose()
+	ctx1 := iso.NewContext()
+	ctx2 := iso.NewContext()
+	ctx1.RunScript(`globalThis.name = "ctx1"`, "main.js").Release()
+	ctx1.SetSecurityToken("tenant")
+	ctx2.SetSecurityToken("tenant")
+	global, err := ctx1.Global().TransferTo(ctx2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer global.Release()
+	ctx2.Global().Set("other", global.Consume())
+	result := ctx2.RunScript(`other.name`, "main.js")
+	if result.String() != "ctx1" {
+		t.Fatal(result.String())
+	}
+	result.Release()
+	ctx2.SetSecurityToken("other")
+	result = ctx2.RunScript(`try { other.name } catch (e) { "denied" }`, "main.js")
+	if result.String() != "denied" {
+		t.Fatal(result.String())
+	}
+	result.Release()
+	ctx2.SetSecurityToken("tenant")
+	ctx2.Reset()
+	global, err = ctx1.Global().TransferTo(ctx2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer global.Release()
+	ctx2.Global().Set("other", global.Consume())
+	result = ctx2.RunScript(`try { other.name } catch (e) { "denied" }`, "main.js")
+	defer result.Release()
+	if result.String() != "ctx1" {
+		t.Fatal(result.String())
+	}
+}
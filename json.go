@@ -0,0 +1,100 @@
+package v8js
+
+import (
+	"math/big"
+
+	"github.com/herb-go/v8go"
+)
+
+var stringifyHelper = registerHelper("json-stringify.js", `((JSON) => function(value, replacer, indent, bigint) {
+	return JSON.stringify(value, function(key, val) {
+		if (typeof val === "bigint" && bigint) {
+			val = bigint.call(this, key, val);
+		}
+		if (replacer) {
+			val = replacer.call(this, key, val);
+		}
+		return val;
+	}, indent);
+})(JSON)`)
+
+// BigIntJSONHook converts bigint value to a json serializable value.
+type BigIntJSONHook func(ctx *Context, key string, value *big.Int) *Consumed
+
+// BigIntJSONString serializes bigint values as decimal strings.
+var BigIntJSONString BigIntJSONHook = func(ctx *Context, key string, value *big.Int) *Consumed {
+	return ctx.NewString(value.String()).Consume()
+}
+
+// BigIntJSONNumber serializes bigint values as numbers.
+// Precision will be lost if value is out of float64 safe integer range.
+var BigIntJSONNumber BigIntJSONHook = func(ctx *Context, key string, value *big.Int) *Consumed {
+	f, _ := new(big.Float).SetInt(value).Float64()
+	return ctx.NewNumber(f).Consume()
+}
+
+// JSONOptions options used by JsValue.JSON.
+type JSONOptions struct {
+	// Indent string used to indent output,no indent if empty.
+	Indent string
+	// Replacer js function called as the JSON.stringify replacer.
+	Replacer *JsValue
+	// BigInt hook used to convert bigint values.
+	// JSON.stringify will throw a TypeError on bigint values if nil.
+	BigInt BigIntJSONHook
+}
+
+func NewJSONOptions() *JSONOptions {
+	return &JSONOptions{}
+}
+
+// JSON stringifies value with given options.
+// Nil options means same as JSON.stringify(value).
+// Return nil data without error if value can not be serialized,like undefined or functions.
+func (v *JsValue) JSON(opt *JSONOptions) (data []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			data = nil
+			err = toError(r)
+		}
+	}()
+	if opt == nil {
+		opt = NewJSONOptions()
+	}
+	ctx := v.ctx
+	h := ctx.helper(stringifyHelper)
+	replacer := ctx.NullValue()
+	if opt.Replacer != nil {
+		replacer = opt.Replacer
+	}
+	bigint := ctx.NullValue()
+	if opt.BigInt != nil {
+		hook := opt.BigInt
+		bigint = ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+			return hook(info.Context(), info.GetArg(0).String(), info.GetArg(1).BigInt())
+		})
+	}
+	result := h.Call(ctx.NullValue(),
+		v.ConsumeReuseble().Consume(),
+		replacer.ConsumeReuseble().Consume(),
+		ctx.NewString(opt.Indent).Consume(),
+		bigint.Consume(),
+	)
+	defer result.Release()
+	if result.IsUndefined() {
+		return nil, nil
+	}
+	return []byte(result.String()), nil
+}
+
+// ParseJSON parses json data to js value.
+func (c *Context) ParseJSON(data []byte) (*JsValue, error) {
+	if c == nil || c.Raw == nil {
+		return nil, ErrContextClosed
+	}
+	val, err := v8go.JSONParse(c.Raw, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return c.Wrap(val), nil
+}
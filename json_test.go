@@ -0,0 +1,51 @@
+package v8js
+
+import "testing"
+
+func TestJSON(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	v, err := ctx.ParseJSON([]byte(`{"name":"test","list":[1,2]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Release()
+	if v.Get("name").String() != "test" || v.Get("list").GetIdx(1).Int32() != 2 {
+		t.Fatal()
+	}
+	if _, err = ctx.ParseJSON([]byte(`{"name"`)); err == nil {
+		t.Fatal(err)
+	}
+	data, err := v.JSON(nil)
+	if err != nil || string(data) != `{"name":"test","list":[1,2]}` {
+		t.Fatal(string(data), err)
+	}
+	opt := NewJSONOptions()
+	opt.Indent = "  "
+	opt.Replacer = ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		if info.GetArg(0).String() == "name" {
+			return info.Context().NewString("replaced").Consume()
+		}
+		return info.GetArg(1)
+	})
+	defer opt.Replacer.Release()
+	data, err = v.JSON(opt)
+	if err != nil || string(data) != "{\n  \"name\": \"replaced\",\n  \"list\": [\n    1,\n    2\n  ]\n}" {
+		t.Fatal(string(data), err)
+	}
+	big := ctx.RunScript(`({id: 12345678901234567890n})`, "bigint.js")
+	defer big.Release()
+	if _, err = big.JSON(nil); err == nil {
+		t.Fatal(err)
+	}
+	opt = NewJSONOptions()
+	opt.BigInt = BigIntJSONString
+	data, err = big.JSON(opt)
+	if err != nil || string(data) != `{"id":"12345678901234567890"}` {
+		t.Fatal(string(data), err)
+	}
+	undefined := ctx.RunScript(`undefined`, "undefined.js")
+	if data, err = undefined.JSON(nil); data != nil || err != nil {
+		t.Fatal(data, err)
+	}
+}
@@ -0,0 +1,91 @@
+package v8js
+
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Kind kind of js value.
+// Values must match order of V8jsValueKind in v8js.cc.
+type Kind int32
+
+const (
+	KindUndefined Kind = iota
+	KindNull
+	KindBoolean
+	KindNumber
+	KindBigInt
+	KindString
+	KindSymbol
+	KindObject
+	KindArray
+	KindFunction
+	KindPromise
+	KindDate
+	KindRegExp
+	KindMap
+	KindSet
+	KindWeakMap
+	KindWeakSet
+	KindArrayBuffer
+	KindSharedArrayBuffer
+	KindTypedArray
+	KindDataView
+	KindError
+	KindProxy
+)
+
+var kindNames = map[Kind]string{
+	KindUndefined:         "Undefined",
+	KindNull:              "Null",
+	KindBoolean:           "Boolean",
+	KindNumber:            "Number",
+	KindBigInt:            "BigInt",
+	KindString:            "String",
+	KindSymbol:            "Symbol",
+	KindObject:            "Object",
+	KindArray:             "Array",
+	KindFunction:          "Function",
+	KindPromise:           "Promise",
+	KindDate:              "Date",
+	KindRegExp:            "RegExp",
+	KindMap:               "Map",
+	KindSet:               "Set",
+	KindWeakMap:           "WeakMap",
+	KindWeakSet:           "WeakSet",
+	KindArrayBuffer:       "ArrayBuffer",
+	KindSharedArrayBuffer: "SharedArrayBuffer",
+	KindTypedArray:        "TypedArray",
+	KindDataView:          "DataView",
+	KindError:             "Error",
+	KindProxy:             "Proxy",
+}
+
+func (k Kind) String() string {
+	name, ok := kindNames[k]
+	if !ok {
+		return "Unknown"
+	}
+	return name
+}
+
+// Kind returns kind of value.
+// Kind is detected by v8 internal type checks in a single native call,which can not be spoofed by Symbol.toStringTag or prototypes.
+// Proxies are reported as KindProxy whatever their targets are.
+func (v *JsValue) Kind() Kind {
+	result := Kind(C.V8jsValueKind(v.ctx.nativeContext(), v.native()))
+	runtime.KeepAlive(v)
+	return result
+}
+
+// TypeOf returns the result of javascript typeof operator.
+// No javascript runs.
+func (v *JsValue) TypeOf() string {
+	result := C.V8jsValueTypeOf(v.ctx.nativeContext(), v.native())
+	runtime.KeepAlive(v)
+	defer C.free(unsafe.Pointer(result))
+	return C.GoString(result)
+}
@@ -0,0 +1,56 @@
+package v8js
+
+import "testing"
+
+func TestKind(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	values := ctx.RunScript(`[
+	undefined, null, true, 1, 1n, "s", Symbol("s"), {}, [], () => 1,
+	Promise.resolve(1), new Date(), /a/, new Map(), new Set(), new WeakMap(), new WeakSet(),
+	new ArrayBuffer(1), new SharedArrayBuffer(1), new Uint8Array(1), new DataView(new ArrayBuffer(1)), new RangeError("e"), new Proxy(function() {}, {}),
+	{[Symbol.toStringTag]: "Map"}, Object.create(Map.prototype)
+]`, "kind.js")
+	defer values.Release()
+	expected := []Kind{
+		KindUndefined, KindNull, KindBoolean, KindNumber, KindBigInt, KindString, KindSymbol, KindObject, KindArray, KindFunction,
+		KindPromise, KindDate, KindRegExp, KindMap, KindSet, KindWeakMap, KindWeakSet,
+		KindArrayBuffer, KindSharedArrayBuffer, KindTypedArray, KindDataView, KindError, KindProxy,
+		KindObject, KindObject,
+	}
+	for i, kind := range expected {
+		v := values.GetIdx(uint32(i))
+		if v.Kind() != kind {
+			t.Fatal(i, v.Kind(), kind)
+		}
+		v.Release()
+	}
+	typeofs := []string{"undefined", "object", "boolean", "number", "bigint", "string", "symbol", "object", "object", "function"}
+	for i, typeof := range typeofs {
+		v := values.GetIdx(uint32(i))
+		if v.TypeOf() != typeof {
+			t.Fatal(i, v.TypeOf(), typeof)
+		}
+		v.Release()
+	}
+	revoked := ctx.RunScript(`const r = Proxy.revocable([], {}); r.revoke(); delete Date.prototype.getTime; r.proxy`, "kind.js")
+	defer revoked.Release()
+	if revoked.Kind() != KindProxy {
+		t.Fatal(revoked.Kind())
+	}
+	date := ctx.RunScript(`new Date()`, "kind.js")
+	defer date.Release()
+	if date.Kind() != KindDate {
+		t.Fatal(date.Kind())
+	}
+	if KindTypedArray.String() != "TypedArray" || Kind(-1).String() != "Unknown" {
+		t.Fatal()
+	}
+	interrupted := false
+	ctx.RequestInterrupt(func(ctx *Context) {
+		interrupted = true
+	})
+	if date.TypeOf() != "object" || interrupted {
+		t.Fatal()
+	}
+}
@@ -0,0 +1,141 @@
+package v8js
+
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/herb-go/v8go"
+)
+
+var ErrModuleNotSupported = errors.New("v8js: es module scripts are not supported")
+var ErrInvalidScriptOrigin = errors.New("v8js: invalid script origin")
+
+// ScriptOrigin origin of script used in error locations and stack traces.
+// Origin is passed to v8 as is,so source of script is not modified.
+// Host defined options are not supported,as dynamic import is not supported by v8go.
+type ScriptOrigin struct {
+	// ResourceName script name.
+	ResourceName string
+	// LineOffset 0-based line offset of script in resource.
+	LineOffset int
+	// ColumnOffset 0-based column offset of first line of script in resource.
+	ColumnOffset int
+	// IsModule if script is an es module.
+	// Modules are not supported by v8go,script with module origin can not be compiled.
+	IsModule bool
+	// SourceMapURL url of source map,same as "//# sourceMappingURL=" comment.
+	SourceMapURL string
+}
+
+func NewScriptOrigin(name string) *ScriptOrigin {
+	return &ScriptOrigin{
+		ResourceName: name,
+	}
+}
+
+func (o *ScriptOrigin) validate() error {
+	if o.IsModule {
+		return ErrModuleNotSupported
+	}
+	if o.LineOffset < 0 || o.ColumnOffset < 0 {
+		return ErrInvalidScriptOrigin
+	}
+	return nil
+}
+
+// native converts origin to native script origin.
+// Returned function frees native strings.
+func (o *ScriptOrigin) native() (C.V8jsScriptOrigin, func()) {
+	origin := C.V8jsScriptOrigin{
+		resource_name: C.CString(o.ResourceName),
+		line_offset:   C.int(o.LineOffset),
+		column_offset: C.int(o.ColumnOffset),
+	}
+	if o.SourceMapURL != "" {
+		origin.source_map_url = C.CString(o.SourceMapURL)
+	}
+	return origin, func() {
+		C.free(unsafe.Pointer(origin.resource_name))
+		C.free(unsafe.Pointer(origin.source_map_url))
+	}
+}
+
+// Script compiled script which can be run in context multiple times.
+type Script struct {
+	ctx    *Context
+	raw    *v8go.UnboundScript
+	origin *ScriptOrigin
+}
+
+// Origin returns origin of script.
+func (s *Script) Origin() *ScriptOrigin {
+	return s.origin
+}
+
+// Run runs script in context which compiled it.
+func (s *Script) Run() *JsValue {
+	c := s.ctx
+	c.enter()
+	defer c.exit()
+	raw, err := s.raw.Run(c.Raw)
+	var result *JsValue
+	if err == nil {
+		result = c.Wrap(raw)
+	}
+	err = c.leave(result, err)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// CompileScript compiles script with given origin.
+// Source map referenced by script or origin will be loaded if source maps enabled.
+func (c *Context) CompileScript(script string, origin *ScriptOrigin) *Script {
+	if err := origin.validate(); err != nil {
+		panic(err)
+	}
+	c.loadSourceMap(script, origin.ResourceName, origin.SourceMapURL)
+	source := C.CString(script)
+	defer C.free(unsafe.Pointer(source))
+	o, free := origin.native()
+	defer free()
+	iso := c.Raw.Isolate()
+	result := C.V8jsCompileScript(C.V8jsIsolatePtr(nativePtr(iso)), source, o)
+	if err := nativeError(result.error); err != nil {
+		panic(c.convertError(err))
+	}
+	return &Script{
+		ctx:    c,
+		raw:    newUnboundScript(result.script, iso),
+		origin: origin,
+	}
+}
+
+// RunScriptWithOrigin runs script with given origin.
+func (c *Context) RunScriptWithOrigin(script string, origin *ScriptOrigin) *JsValue {
+	if err := origin.validate(); err != nil {
+		panic(err)
+	}
+	c.loadSourceMap(script, origin.ResourceName, origin.SourceMapURL)
+	source := C.CString(script)
+	defer C.free(unsafe.Pointer(source))
+	o, free := origin.native()
+	defer free()
+	c.enter()
+	defer c.exit()
+	rtn := C.V8jsRunScript(c.nativeContext(), source, o)
+	err := nativeError(rtn.error)
+	var result *JsValue
+	if err == nil {
+		result = c.wrapNative(rtn.value)
+	}
+	err = c.leave(result, err)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package v8js
+
+import (
+	"strings"
+	"testing"
+)
+
+func catchJSError(fn func()) (err *JSError) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = ToJSError(r.(error))
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestScriptOrigin(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	origin := &ScriptOrigin{ResourceName: "page.md", LineOffset: 9, ColumnOffset: 4}
+	err := catchJSError(func() {
+		ctx.RunScriptWithOrigin("throw new Error('e')", origin)
+	})
+	if err == nil || err.Location != "page.md:10:5" || err.Frames[0].Column != 11 {
+		t.Fatal(err)
+	}
+	err = catchJSError(func() {
+		ctx.RunScriptWithOrigin("1;\nthrow new Error('e')", origin)
+	})
+	if err == nil || err.Frames[0].Line != 11 || err.Frames[0].Column != 7 {
+		t.Fatal(err)
+	}
+	script := ctx.CompileScript("[function() {}, 1 + 2]", &ScriptOrigin{ResourceName: "tpl.js", SourceMapURL: "tpl.js.map"})
+	if script.Origin().ResourceName != "tpl.js" {
+		t.Fatal()
+	}
+	for i := 0; i < 2; i++ {
+		result := script.Run()
+		if result.GetIdx(1).Int32() != 3 || result.GetIdx(0).SourceMapURL() != "tpl.js.map" {
+			t.Fatal()
+		}
+		result.Release()
+	}
+	err = catchJSError(func() {
+		ctx.CompileScript("1 +", &ScriptOrigin{ResourceName: "broken.js", LineOffset: 2})
+	})
+	if err == nil || !strings.HasPrefix(err.Location, "broken.js:3") {
+		t.Fatal(err)
+	}
+}
+
+func TestScriptOriginSource(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	origin := &ScriptOrigin{ResourceName: "bin.js", LineOffset: 3, ColumnOffset: 2}
+	result := ctx.RunScriptWithOrigin("#!/usr/bin/env node\n(function f() { return 1 })", origin)
+	defer result.Release()
+	if result.String() != "function f() { return 1 }" {
+		t.Fatal(result.String())
+	}
+	loc := result.FunctionLocation()
+	if loc == nil || loc.Line != 5 || loc.Column != 12 {
+		t.Fatal(loc)
+	}
+}
+
+func TestScriptOriginInvalid(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	for _, origin := range []*ScriptOrigin{{IsModule: true}, {LineOffset: -1}} {
+		func() {
+			defer func() {
+				r := recover()
+				if r != ErrModuleNotSupported && r != ErrInvalidScriptOrigin {
+					t.Fatal(r)
+				}
+			}()
+			ctx.RunScriptWithOrigin("1", origin)
+		}()
+	}
+}
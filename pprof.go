@@ -0,0 +1,152 @@
+package v8js
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// protoBuffer minimal protocol buffers encoder used to write pprof profiles.
+type protoBuffer struct {
+	data []byte
+}
+
+func (b *protoBuffer) varint(v uint64) {
+	for v >= 0x80 {
+		b.data = append(b.data, byte(v)|0x80)
+		v >>= 7
+	}
+	b.data = append(b.data, byte(v))
+}
+func (b *protoBuffer) key(field int, wire int) {
+	b.varint(uint64(field)<<3 | uint64(wire))
+}
+func (b *protoBuffer) int64(field int, v int64) {
+	if v == 0 {
+		return
+	}
+	b.key(field, 0)
+	b.varint(uint64(v))
+}
+func (b *protoBuffer) int64s(field int, v []int64) {
+	if len(v) == 0 {
+		return
+	}
+	packed := &protoBuffer{}
+	for _, i := range v {
+		packed.varint(uint64(i))
+	}
+	b.bytes(field, packed.data)
+}
+func (b *protoBuffer) bytes(field int, v []byte) {
+	b.key(field, 2)
+	b.varint(uint64(len(v)))
+	b.data = append(b.data, v...)
+}
+func (b *protoBuffer) message(field int, fn func(m *protoBuffer)) {
+	m := &protoBuffer{}
+	fn(m)
+	b.bytes(field, m.data)
+}
+
+type pprofWriter struct {
+	strings     []string
+	stringIndex map[string]int64
+	functions   map[string]int64
+	buf         *protoBuffer
+}
+
+func (w *pprofWriter) str(s string) int64 {
+	idx, ok := w.stringIndex[s]
+	if !ok {
+		idx = int64(len(w.strings))
+		w.strings = append(w.strings, s)
+		w.stringIndex[s] = idx
+	}
+	return idx
+}
+
+func (w *pprofWriter) function(n *CPUProfileNode) int64 {
+	name := n.FunctionName
+	if name == "" {
+		name = "(anonymous)"
+	}
+	key := name + "\x00" + n.URL
+	id, ok := w.functions[key]
+	if !ok {
+		id = int64(len(w.functions) + 1)
+		w.functions[key] = id
+		nameidx, fileidx := w.str(name), w.str(n.URL)
+		w.buf.message(5, func(m *protoBuffer) {
+			m.int64(1, id)
+			m.int64(2, nameidx)
+			m.int64(3, nameidx)
+			m.int64(4, fileidx)
+		})
+	}
+	return id
+}
+
+// WritePprof writes profile in gzipped pprof protobuf format,which can be read by go tool pprof.
+// Each profile node is written as a location,and nodes with hits are written as samples
+// with "samples/count" and "cpu/nanoseconds" values.
+func (p *CPUProfile) WritePprof(w io.Writer) error {
+	pw := &pprofWriter{
+		stringIndex: map[string]int64{},
+		functions:   map[string]int64{},
+		buf:         &protoBuffer{},
+	}
+	pw.str("")
+	samples, count := pw.str("samples"), pw.str("count")
+	cpu, nanoseconds := pw.str("cpu"), pw.str("nanoseconds")
+	buf := pw.buf
+	buf.message(1, func(m *protoBuffer) {
+		m.int64(1, samples)
+		m.int64(2, count)
+	})
+	buf.message(1, func(m *protoBuffer) {
+		m.int64(1, cpu)
+		m.int64(2, nanoseconds)
+	})
+	var period int64
+	if p.TotalHits > 0 {
+		period = int64(p.Duration) / int64(p.TotalHits)
+	}
+	p.Root.Walk(func(n *CPUProfileNode) {
+		if n.HitCount == 0 {
+			return
+		}
+		stack := []int64{}
+		for node := n; node != nil; node = node.Parent {
+			stack = append(stack, int64(node.ID))
+		}
+		buf.message(2, func(m *protoBuffer) {
+			m.int64s(1, stack)
+			m.int64s(2, []int64{int64(n.HitCount), int64(n.SelfTime)})
+		})
+	})
+	p.Root.Walk(func(n *CPUProfileNode) {
+		fn := pw.function(n)
+		buf.message(4, func(m *protoBuffer) {
+			m.int64(1, int64(n.ID))
+			m.message(4, func(line *protoBuffer) {
+				line.int64(1, fn)
+				line.int64(2, int64(n.Line))
+			})
+		})
+	})
+	for _, s := range pw.strings {
+		buf.bytes(6, []byte(s))
+	}
+	buf.int64(9, p.StartTime.UnixNano())
+	buf.int64(10, int64(p.Duration))
+	buf.message(11, func(m *protoBuffer) {
+		m.int64(1, cpu)
+		m.int64(2, nanoseconds)
+	})
+	buf.int64(12, period)
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(buf.data); err != nil {
+		return err
+	}
+	return zw.Close()
+}
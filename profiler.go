@@ -0,0 +1,230 @@
+package v8js
+
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/herb-go/v8go"
+)
+
+var ErrProfilingStarted = errors.New("v8js: cpu profiling already started")
+var ErrProfilingNotStarted = errors.New("v8js: cpu profiling not started")
+
+// CPUProfileNode node of cpu profile call tree.
+type CPUProfileNode struct {
+	ID           int
+	ScriptID     int
+	FunctionName string
+	URL          string
+	// Line 1-based line number,0 if unknown.
+	Line int
+	// Column 1-based column number,0 if unknown.
+	Column        int
+	HitCount      int
+	BailoutReason string
+	// SelfTime time spent in function itself,sum of intervals of samples of node.
+	SelfTime time.Duration
+	// TotalTime time spent in function and its callees.
+	TotalTime time.Duration
+	Parent    *CPUProfileNode
+	Children  []*CPUProfileNode
+}
+
+// Walk calls fn with node and all its descendants in depth-first order.
+func (n *CPUProfileNode) Walk(fn func(node *CPUProfileNode)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// CPUProfileSample sample recorded by cpu profiler.
+type CPUProfileSample struct {
+	// NodeID id of node running when sampled.
+	NodeID int
+	// Time time since profile started.
+	Time time.Duration
+}
+
+// CPUProfile cpu profile created by Context.StopProfiling.
+//
+// Samples and durations are recorded by v8,
+// StartTime is wall clock time when profiling started.
+type CPUProfile struct {
+	Title     string
+	StartTime time.Time
+	Duration  time.Duration
+	TotalHits int
+	Root      *CPUProfileNode
+	Samples   []CPUProfileSample
+}
+
+func convertCPUProfileNode(n *v8go.CPUProfileNode, parent *CPUProfileNode) *CPUProfileNode {
+	node := &CPUProfileNode{
+		ID:            n.GetNodeId(),
+		ScriptID:      n.GetScriptId(),
+		FunctionName:  n.GetFunctionName(),
+		URL:           n.GetScriptResourceName(),
+		Line:          n.GetLineNumber(),
+		Column:        n.GetColumnNumber(),
+		HitCount:      n.GetHitCount(),
+		BailoutReason: n.GetBailoutReason(),
+		Parent:        parent,
+	}
+	count := n.GetChildrenCount()
+	node.Children = make([]*CPUProfileNode, count)
+	for i := 0; i < count; i++ {
+		node.Children[i] = convertCPUProfileNode(n.GetChild(i), node)
+	}
+	return node
+}
+
+// readSamples reads samples and duration of raw profile recorded by v8.
+func (p *CPUProfile) readSamples(raw *v8go.CPUProfile) {
+	samples := C.V8jsCPUProfileGetSamples(reflect.ValueOf(raw).Elem().FieldByName("p").UnsafePointer())
+	p.Duration = time.Duration(samples.end-samples.start) * time.Microsecond
+	if samples.length == 0 {
+		return
+	}
+	defer C.free(unsafe.Pointer(samples.nodes))
+	defer C.free(unsafe.Pointer(samples.timestamps))
+	nodes := unsafe.Slice(samples.nodes, int(samples.length))
+	timestamps := unsafe.Slice(samples.timestamps, int(samples.length))
+	p.Samples = make([]CPUProfileSample, len(nodes))
+	for k := range nodes {
+		p.Samples[k] = CPUProfileSample{
+			NodeID: int(nodes[k]),
+			Time:   time.Duration(timestamps[k]-samples.start) * time.Microsecond,
+		}
+	}
+}
+
+// computeTimes computes times of node and its descendants,
+// each sample lasts until next sample,or until profile end for last sample.
+func (p *CPUProfile) computeTimes(n *CPUProfileNode) {
+	self := map[int]time.Duration{}
+	for k, sample := range p.Samples {
+		end := p.Duration
+		if k+1 < len(p.Samples) {
+			end = p.Samples[k+1].Time
+		}
+		self[sample.NodeID] += end - sample.Time
+	}
+	var walk func(n *CPUProfileNode) time.Duration
+	walk = func(n *CPUProfileNode) time.Duration {
+		n.SelfTime = self[n.ID]
+		n.TotalTime = n.SelfTime
+		for _, child := range n.Children {
+			n.TotalTime += walk(child)
+		}
+		return n.TotalTime
+	}
+	walk(n)
+}
+
+type chromeCallFrame struct {
+	FunctionName string `json:"functionName"`
+	ScriptID     string `json:"scriptId"`
+	URL          string `json:"url"`
+	LineNumber   int    `json:"lineNumber"`
+	ColumnNumber int    `json:"columnNumber"`
+}
+
+type chromeProfileNode struct {
+	ID          int             `json:"id"`
+	CallFrame   chromeCallFrame `json:"callFrame"`
+	HitCount    int             `json:"hitCount"`
+	Children    []int           `json:"children,omitempty"`
+	DeoptReason string          `json:"deoptReason,omitempty"`
+}
+
+type chromeProfile struct {
+	Nodes      []*chromeProfileNode `json:"nodes"`
+	StartTime  int64                `json:"startTime"`
+	EndTime    int64                `json:"endTime"`
+	Samples    []int                `json:"samples"`
+	TimeDeltas []int64              `json:"timeDeltas"`
+}
+
+// WriteCPUProfile writes profile in chrome .cpuprofile json format,which can be loaded by Chrome DevTools.
+func (p *CPUProfile) WriteCPUProfile(w io.Writer) error {
+	start := p.StartTime.UnixMicro()
+	output := &chromeProfile{
+		StartTime:  start,
+		EndTime:    start + p.Duration.Microseconds(),
+		Samples:    []int{},
+		TimeDeltas: []int64{},
+	}
+	p.Root.Walk(func(n *CPUProfileNode) {
+		node := &chromeProfileNode{
+			ID: n.ID,
+			CallFrame: chromeCallFrame{
+				FunctionName: n.FunctionName,
+				ScriptID:     strconv.Itoa(n.ScriptID),
+				URL:          n.URL,
+				LineNumber:   n.Line - 1,
+				ColumnNumber: n.Column - 1,
+			},
+			HitCount:    n.HitCount,
+			DeoptReason: n.BailoutReason,
+		}
+		for _, child := range n.Children {
+			node.Children = append(node.Children, child.ID)
+		}
+		output.Nodes = append(output.Nodes, node)
+	})
+	var last int64
+	for _, sample := range p.Samples {
+		output.Samples = append(output.Samples, sample.NodeID)
+		output.TimeDeltas = append(output.TimeDeltas, sample.Time.Microseconds()-last)
+		last = sample.Time.Microseconds()
+	}
+	return json.NewEncoder(w).Encode(output)
+}
+
+// StartProfiling starts cpu profiling with given name.
+// Only one profiling can be started in a context at same time.
+func (c *Context) StartProfiling(name string) {
+	if c.profiler != nil {
+		panic(ErrProfilingStarted)
+	}
+	c.profiler = v8go.NewCPUProfiler(c.Raw.Isolate())
+	c.profileName = name
+	c.profileStartedAt = time.Now()
+	// started natively,as profiling started by v8go records no samples.
+	title := C.CString(name)
+	defer C.free(unsafe.Pointer(title))
+	C.V8jsCPUProfilerStartProfiling(reflect.ValueOf(c.profiler).Elem().FieldByName("p").UnsafePointer(), title)
+}
+
+// StopProfiling stops cpu profiling started by StartProfiling and returns the profile.
+func (c *Context) StopProfiling() *CPUProfile {
+	if c.profiler == nil {
+		panic(ErrProfilingNotStarted)
+	}
+	profiler := c.profiler
+	c.profiler = nil
+	defer profiler.Dispose()
+	raw := profiler.StopProfiling(c.profileName)
+	defer raw.Delete()
+	p := &CPUProfile{
+		Title:     raw.GetTitle(),
+		StartTime: c.profileStartedAt,
+		Root:      convertCPUProfileNode(raw.GetTopDownRoot(), nil),
+	}
+	// samples and duration are read natively,as v8go treats v8 microsecond timestamps as milliseconds.
+	p.readSamples(raw)
+	p.Root.Walk(func(n *CPUProfileNode) {
+		p.TotalHits += n.HitCount
+	})
+	p.computeTimes(p.Root)
+	return p
+}
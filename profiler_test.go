@@ -0,0 +1,146 @@
+package v8js
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+)
+
+func newTestCPUProfile() *CPUProfile {
+	root := &CPUProfileNode{ID: 1, FunctionName: "(root)"}
+	main := &CPUProfileNode{ID: 2, FunctionName: "main", URL: "main.js", Line: 1, Column: 1, HitCount: 1, Parent: root}
+	fib := &CPUProfileNode{ID: 3, FunctionName: "fib", URL: "fib.js", Line: 2, Column: 13, HitCount: 3, Parent: main}
+	anonymous := &CPUProfileNode{ID: 4, URL: "main.js", Line: 5, Column: 3, Parent: main}
+	root.Children = []*CPUProfileNode{main}
+	main.Children = []*CPUProfileNode{fib, anonymous}
+	p := &CPUProfile{
+		Title:     "test",
+		StartTime: time.Unix(100, 0),
+		Duration:  4 * time.Millisecond,
+		TotalHits: 4,
+		Root:      root,
+		Samples: []CPUProfileSample{
+			{NodeID: 2, Time: 0},
+			{NodeID: 3, Time: time.Millisecond},
+			{NodeID: 3, Time: 1500 * time.Microsecond},
+			{NodeID: 3, Time: 3 * time.Millisecond},
+		},
+	}
+	p.computeTimes(root)
+	return p
+}
+
+func TestCPUProfileTimes(t *testing.T) {
+	p := newTestCPUProfile()
+	main, fib := p.Root.Children[0], p.Root.Children[0].Children[0]
+	if main.SelfTime != time.Millisecond || main.TotalTime != 4*time.Millisecond || fib.SelfTime != 3*time.Millisecond || fib.TotalTime != 3*time.Millisecond {
+		t.Fatal(main, fib)
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := p.WriteCPUProfile(buf); err != nil {
+		t.Fatal(err)
+	}
+	output := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatal(err)
+	}
+	if len(output["samples"].([]interface{})) != p.TotalHits || len(output["nodes"].([]interface{})) != 4 {
+		t.Fatal(output)
+	}
+	deltas := output["timeDeltas"].([]interface{})
+	if deltas[0] != float64(0) || deltas[1] != float64(1000) || deltas[2] != float64(500) || deltas[3] != float64(1500) || output["endTime"].(float64)-output["startTime"].(float64) != 4000 {
+		t.Fatal(output)
+	}
+}
+
+func TestWritePprof(t *testing.T) {
+	p := newTestCPUProfile()
+	buf := bytes.NewBuffer(nil)
+	if err := p.WritePprof(buf); err != nil {
+		t.Fatal(err)
+	}
+	prof, err := profile.Parse(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := prof.CheckValid(); err != nil {
+		t.Fatal(err)
+	}
+	if len(prof.SampleType) != 2 || prof.SampleType[0].Type != "samples" || prof.SampleType[1].Type != "cpu" || prof.SampleType[1].Unit != "nanoseconds" {
+		t.Fatal(prof.SampleType)
+	}
+	if prof.PeriodType.Type != "cpu" || prof.Period != int64(time.Millisecond) || prof.TimeNanos != p.StartTime.UnixNano() || prof.DurationNanos != int64(p.Duration) {
+		t.Fatal(prof.PeriodType, prof.Period, prof.TimeNanos, prof.DurationNanos)
+	}
+	if len(prof.Location) != 4 || len(prof.Sample) != 2 {
+		t.Fatal(prof.Location, prof.Sample)
+	}
+	var fib *profile.Sample
+	for _, s := range prof.Sample {
+		if s.Location[0].Line[0].Function.Name == "fib" {
+			fib = s
+		}
+	}
+	if fib == nil || fib.Value[0] != 3 || fib.Value[1] != int64(3*time.Millisecond) || len(fib.Location) != 3 {
+		t.Fatal(fib)
+	}
+	leaf, caller := fib.Location[0].Line[0], fib.Location[1].Line[0]
+	if leaf.Function.Filename != "fib.js" || leaf.Line != 2 || caller.Function.Name != "main" || caller.Function.Filename != "main.js" {
+		t.Fatal(leaf, caller)
+	}
+	for _, loc := range prof.Location {
+		if loc.ID == 4 && loc.Line[0].Function.Name != "(anonymous)" {
+			t.Fatal(loc.Line[0].Function)
+		}
+	}
+}
+
+func TestProfiler(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	ctx.StartProfiling("test")
+	ctx.RunScript(`
+function fib(n) {
+	return n < 2 ? n : fib(n - 1) + fib(n - 2);
+}
+fib(25);
+`, "fib.js")
+	p := ctx.StopProfiling()
+	if p.Title != "test" || p.Root == nil || p.Duration <= 0 || len(p.Samples) == 0 {
+		t.Fatal(p)
+	}
+	nodes := map[int]*CPUProfileNode{}
+	p.Root.Walk(func(n *CPUProfileNode) {
+		nodes[n.ID] = n
+	})
+	var last time.Duration
+	for _, sample := range p.Samples {
+		if nodes[sample.NodeID] == nil || sample.Time < last || sample.Time > p.Duration {
+			t.Fatal(sample, p.Duration)
+		}
+		last = sample.Time
+	}
+	if p.Root.TotalTime != p.Duration-p.Samples[0].Time {
+		t.Fatal(p.Root.TotalTime, p.Duration, p.Samples[0].Time)
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := p.WritePprof(buf); err != nil {
+		t.Fatal(err)
+	}
+	prof, err := profile.Parse(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := prof.CheckValid(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() != ErrProfilingNotStarted {
+			t.Fatal()
+		}
+	}()
+	ctx.StopProfiling()
+}
@@ -0,0 +1,62 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"runtime"
+)
+
+var proxyHelper = registerHelper("proxy.js", "Proxy")
+
+// NewProxy creates a new Proxy object for target with given handler.
+// Handler traps can be JS functions or Go callbacks created by NewProxyHandler.
+func (c *Context) NewProxy(target *Consumed, handler *Consumed) *JsValue {
+	p := c.helper(proxyHelper)
+	return p.New(target, handler)
+}
+
+// NewProxyHandler creates a proxy handler object with Go callbacks as traps.
+// Traps map trap name (get,set,has,apply,construct...) to callback.
+// Callback receives the same arguments as the JS trap.
+func (c *Context) NewProxyHandler(traps map[string]FunctionCallback) *JsValue {
+	handler := c.NewObject()
+	for name, cb := range traps {
+		handler.SetObjectMethod(c, name, cb)
+	}
+	return handler
+}
+
+// Reflect returns the global Reflect object,which can be used in traps to forward operations to target.
+func (c *Context) Reflect() *JsValue {
+	global := c.Global()
+	defer global.Release()
+	return global.Get("Reflect")
+}
+
+func (v *JsValue) IsProxy() bool {
+	result := v.export().IsProxy()
+	runtime.KeepAlive(v)
+	return result
+}
+
+// ProxyTarget returns the target of proxy value.
+// Return null value if value is not a proxy or proxy is revoked.
+func (v *JsValue) ProxyTarget() *JsValue {
+	result := v.ctx.wrapNative(C.V8jsProxyGetTarget(v.ctx.nativeContext(), v.native()))
+	runtime.KeepAlive(v)
+	if result == nil {
+		return v.ctx.NullValue()
+	}
+	return result
+}
+
+// ProxyHandler returns the handler of proxy value.
+// Return null value if value is not a proxy or proxy is revoked.
+func (v *JsValue) ProxyHandler() *JsValue {
+	result := v.ctx.wrapNative(C.V8jsProxyGetHandler(v.ctx.nativeContext(), v.native()))
+	runtime.KeepAlive(v)
+	if result == nil {
+		return v.ctx.NullValue()
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package v8js
+
+import "testing"
+
+func TestProxy(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	target := ctx.NewObject()
+	target.Set("name", ctx.NewString("target").Consume())
+	handler := ctx.NewProxyHandler(map[string]FunctionCallback{
+		"get": func(info *FunctionCallbackInfo) *Consumed {
+			if info.GetArg(1).String() == "hooked" {
+				return info.Context().NewString("fromgo").Consume()
+			}
+			reflect := info.Context().Reflect()
+			defer reflect.Release()
+			return reflect.MethodCall("get", info.GetArg(0), info.GetArg(1)).Consume()
+		},
+	})
+	tc := target.ConsumeReuseble()
+	hc := handler.ConsumeReuseble()
+	p := ctx.NewProxy(tc.Consume(), hc.Consume())
+	if !p.IsProxy() || target.IsProxy() {
+		t.Fatal()
+	}
+	if v := p.Get("hooked").String(); v != "fromgo" {
+		t.Fatal(v)
+	}
+	if v := p.Get("name").String(); v != "target" {
+		t.Fatal(v)
+	}
+	if !p.ProxyTarget().SameValue(target) || !p.ProxyHandler().SameValue(handler) {
+		t.Fatal()
+	}
+	if !target.ProxyTarget().IsNull() {
+		t.Fatal()
+	}
+	scripted := ctx.RunScript(`const t={a:1};const h={};[new Proxy(t,h),t,h,Proxy.revocable(t,h).proxy]`, "test.js")
+	sp := scripted.GetIdx(0)
+	if !sp.IsProxy() || !sp.ProxyTarget().SameValue(scripted.GetIdx(1)) || !sp.ProxyHandler().SameValue(scripted.GetIdx(2)) {
+		t.Fatal()
+	}
+	if !scripted.GetIdx(3).ProxyTarget().SameValue(scripted.GetIdx(1)) {
+		t.Fatal()
+	}
+	if ctx.RunScript(`typeof Proxy === "function" && Proxy.name === "Proxy" && String(Proxy.revocable).includes("[native code]")`, "test.js").Boolean() != true {
+		t.Fatal()
+	}
+	revoked := ctx.RunScript(`const r=Proxy.revocable({},{});r.revoke();r.proxy`, "test.js")
+	if !revoked.IsProxy() || !revoked.ProxyTarget().IsNull() || !revoked.ProxyHandler().IsNull() {
+		t.Fatal()
+	}
+}
@@ -0,0 +1,156 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"runtime"
+)
+
+// UnhandledRejectionHandler handler called when a promise rejected without handler.
+type UnhandledRejectionHandler func(reason *JsValue, promise *JsValue)
+
+// UncaughtExceptionHandler handler called when an exception is not caught by javascript.
+type UncaughtExceptionHandler func(err *JSError)
+
+// OnUnhandledRejection registers handler called when a promise created in the context rejects
+// and no then,catch or finally handler is attached to it.
+//
+// Rejections are tracked by v8,including promises created and dropped inside javascript.
+// A rejection is reported when the next top level RunScript,Call or New call finished,
+// so Go code has a chance to attach handlers to promise returned by the call which rejected it,
+// or in PerformMicrotaskCheckpoint,which reports all pending rejections.
+// Reason and promise are released after handlers called.
+func (c *Context) OnUnhandledRejection(h UnhandledRejectionHandler) {
+	if len(c.rejectionHandlers) == 0 {
+		C.V8jsTrackRejections(c.nativeContext())
+	}
+	c.rejectionHandlers = append(c.rejectionHandlers, h)
+}
+
+// OnUncaughtException registers handler called when an exception thrown by javascript
+// is caught by neither javascript nor a Go caller,such as exceptions thrown by microtasks.
+//
+// Exceptions returned to Go callers of RunScript,Call and New are panicked to callers only.
+func (c *Context) OnUncaughtException(h UncaughtExceptionHandler) {
+	if len(c.exceptionHandlers) == 0 {
+		C.V8jsListenUncaughtExceptions(C.V8jsIsolatePtr(nativePtr(c.Raw.Isolate())))
+	}
+	c.exceptionHandlers = append(c.exceptionHandlers, h)
+}
+
+//export v8jsUncaughtException
+func v8jsUncaughtException(ref C.int, value C.V8jsValuePtr) {
+	c := contextByRef(int(ref))
+	if c == nil || c.Raw == nil || len(c.exceptionHandlers) == 0 {
+		releaseNative(value)
+		return
+	}
+	v := c.wrapNative(value)
+	defer v.Release()
+	err := JSErrorFromValue(v)
+	c.mapJSError(err)
+	for _, h := range c.exceptionHandlers {
+		h(err)
+	}
+}
+
+// EnqueueMicrotask queues function to be called without arguments when microtasks run.
+// Exceptions thrown by function are reported to uncaught exception handlers.
+func (c *Context) EnqueueMicrotask(fn *Consumed) {
+	defer fn.Release()
+	mustAsFunction(fn.export())
+	C.V8jsEnqueueMicrotask(c.nativeContext(), fn.native())
+	runtime.KeepAlive(fn)
+}
+
+// PerformMicrotaskCheckpoint runs pending microtasks,such as promise reactions,
+// then reports all pending unhandled rejections.
+func (c *Context) PerformMicrotaskCheckpoint() {
+	c.enter()
+	defer c.exit()
+	c.Raw.PerformMicrotaskCheckpoint()
+	if c.depth == 1 {
+		c.reportRejections(true)
+	}
+}
+
+// reportRejections reports unhandled rejections to handlers.
+// Only rejections pending before previous report are reported unless all is true.
+func (c *Context) reportRejections(all bool) {
+	if len(c.rejectionHandlers) == 0 {
+		return
+	}
+	flag := C.int(0)
+	if all {
+		flag = 1
+	}
+	list := c.wrapNative(C.V8jsTakeRejections(c.nativeContext(), (*C.uint64_t)(&c.rejectionMark), flag))
+	if list == nil {
+		return
+	}
+	defer list.Release()
+	length := list.Get("length")
+	ln := int(length.Integer())
+	length.Release()
+	for i := 0; i+1 < ln; i += 2 {
+		reason := list.GetIdx(uint32(i))
+		promise := list.GetIdx(uint32(i + 1))
+		func() {
+			defer reason.Release()
+			defer promise.Release()
+			for _, h := range c.rejectionHandlers {
+				h(reason, promise)
+			}
+		}()
+	}
+}
+
+// enter starts a call into javascript,exit must be deferred after enter returned.
+// Pending interrupts run before top level calls.
+// Panics with ErrStackOverflow if reentrancy limit of isolate reached.
+func (c *Context) enter() {
+	if c.isolate.depth.Load() == 0 {
+		if err := c.runInterrupts(); err != nil {
+			panic(err)
+		}
+		if c.inspector != nil {
+			c.inspector.enter()
+		}
+		c.isolate.enterStack()
+	}
+	c.isolate.enter()
+	c.depth++
+}
+
+// exit ends a call into javascript started by enter.
+func (c *Context) exit() {
+	c.depth--
+	c.isolate.exit()
+	if c.isolate.depth.Load() == 0 {
+		// lockers are released in reverse order.
+		c.isolate.exitStack()
+		if c.inspector != nil {
+			c.inspector.exit()
+		}
+	}
+}
+
+// leave converts result of a call into javascript started by enter,before exit called.
+// Error returned by v8go will be mapped through source maps,
+// termination caused by panic in interrupt is replaced by the panicked error when top level call finished.
+//
+// Unhandled rejections pending before previous top level call are reported when a top level call finished.
+func (c *Context) leave(result *JsValue, err error) error {
+	if c.isolate.depth.Load() == 1 && c.isolate.interruptErr != nil {
+		interruptErr := c.isolate.interruptErr
+		c.isolate.interruptErr = nil
+		if err != nil {
+			return interruptErr
+		}
+	}
+	if c.depth > 1 || err != nil {
+		return c.convertError(err)
+	}
+	c.reportRejections(false)
+	return nil
+}
@@ -0,0 +1,106 @@
+package v8js
+
+import (
+	"testing"
+
+	"github.com/herb-go/v8go"
+)
+
+func TestUnhandledRejection(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	var reasons []string
+	ctx.OnUnhandledRejection(func(reason *JsValue, promise *JsValue) {
+		if promise.Kind() != KindPromise {
+			t.Fatal()
+		}
+		reasons = append(reasons, JSErrorFromValue(reason).Message)
+	})
+	fns := ctx.RunScript(`[
+	async function(msg) { throw new Error(msg) },
+	async function(msg) { return msg },
+]`, "rejection.js")
+	defer fns.Release()
+	rejected := fns.GetIdx(0)
+	resolved := fns.GetIdx(1)
+	p := rejected.Call(ctx.NullValue(), ctx.NewString("unhandled").Consume())
+	p.Release()
+	resolved.Call(ctx.NullValue(), ctx.NewString("ok").Consume()).Release()
+	if len(reasons) != 1 || reasons[0] != "Error: unhandled" {
+		t.Fatal(reasons)
+	}
+	p = rejected.Call(ctx.NullValue(), ctx.NewString("handled").Consume())
+	p.MethodCall("catch", ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed { return nil }).Consume()).Release()
+	ctx.RunScript(`Promise.reject(new Error("dropped")); Promise.reject(new Error("late")).catch(() => {}); 1`, "rejection.js").Release()
+	ctx.PerformMicrotaskCheckpoint()
+	if len(reasons) != 2 || reasons[1] != "Error: dropped" {
+		t.Fatal(reasons)
+	}
+	other := ctx.Isolate().NewContext()
+	defer other.Close()
+	other.RunScript(`Promise.reject(new Error("other"))`, "rejection.js").Release()
+	ctx.PerformMicrotaskCheckpoint()
+	if len(reasons) != 2 {
+		t.Fatal(reasons)
+	}
+}
+
+func TestUncaughtException(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	var errs []*JSError
+	ctx.OnUncaughtException(func(err *JSError) {
+		errs = append(errs, err)
+	})
+	fn := ctx.RunScript(`(function(cb) {
+	try {
+		cb();
+	} catch (e) {
+	}
+	function inner() {
+		throw new TypeError("uncaught");
+	}
+	inner();
+})`, "uncaught.js")
+	defer fn.Release()
+	cb := ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		info.Context().RunScript(`throw new Error("caught")`, "caught.js")
+		return nil
+	})
+	var recovered interface{}
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		fn.Call(ctx.NullValue(), cb.Consume())
+	}()
+	if _, ok := recovered.(*v8go.JSError); !ok || len(errs) != 0 {
+		t.Fatal(recovered, errs)
+	}
+	err := ToJSError(recovered.(error))
+	if err.Message != "TypeError: uncaught" || len(err.Frames) != 2 {
+		t.Fatal(err.Message, err.StackTrace)
+	}
+	frame := err.Frames[0]
+	if frame.Function != "inner" || frame.Script != "uncaught.js" || frame.Line != 7 || frame.Column != 9 {
+		t.Fatal(frame)
+	}
+	task := ctx.RunScript(`(function task() {
+	throw new RangeError("microtask");
+})`, "task.js")
+	ctx.EnqueueMicrotask(task.Consume())
+	ctx.PerformMicrotaskCheckpoint()
+	if len(errs) != 1 || errs[0].Message != "RangeError: microtask" || errs[0].Location != "task.js:2:8" {
+		t.Fatal(errs)
+	}
+}
+
+func TestParseStackTrace(t *testing.T) {
+	frames := ParseStackTrace("Error: msg\n    at fn (main.js:1:2)\n    at new Foo (lib/a.js:10:20)\n    at main.js:3:4\n    at <anonymous>")
+	if len(frames) != 4 {
+		t.Fatal(frames)
+	}
+	if frames[0].String() != "fn (main.js:1:2)" || frames[1].Function != "new Foo" || frames[1].Script != "lib/a.js" || frames[2].Line != 3 || frames[3].Script != "<anonymous>" {
+		t.Fatal(frames)
+	}
+}
@@ -0,0 +1,97 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"errors"
+
+	"github.com/herb-go/v8go"
+)
+
+var ErrContextClosed = errors.New("v8js: context closed")
+var ErrResetInsideCall = errors.New("v8js: context reset inside javascript call")
+
+// AddSetupHook runs fn in context,and runs it again every time context reset.
+// Setup hooks are used to install globals,consoles and other javascript state which should survive Reset.
+func (c *Context) AddSetupHook(fn func(ctx *Context)) {
+	c.setupHooks = append(c.setupHooks, fn)
+	c.setup(fn)
+}
+
+// setup runs fn as setup hook,policies applied by fn are not recorded as they are applied again with fn.
+func (c *Context) setup(fn func(ctx *Context)) {
+	settingUp := c.settingUp
+	c.settingUp = true
+	defer func() {
+		c.settingUp = settingUp
+	}()
+	fn(c)
+}
+
+// keepPolicy records policy applied outside setup hooks,
+// so Reset applies it again after setup hooks,in order policies applied.
+func (c *Context) keepPolicy(fn func(ctx *Context)) {
+	if !c.settingUp {
+		c.policies = append(c.policies, fn)
+	}
+}
+
+// Reset discards javascript global and all values of context,
+// and creates fresh context on same isolate with options used to create context,
+// then runs setup hooks added by AddSetupHook.
+// Isolate is not disposed,so reset is much cheaper than closing context and creating new one.
+//
+// Values created before reset must not be used after reset,releasing them does nothing.
+// Policies applied by DisableWasm,DisallowCodeGeneration,ApplySandbox and EnableSourceMaps outside setup hooks
+// are applied again after setup hooks,in order they applied.
+// Other javascript state,such as globals and source maps registered by RegisterSourceMap,is discarded.
+// Pending interrupts and context data are discarded too.
+//
+// Go side state kept by reset is exception handlers,unhandled rejection handlers,setup hooks,security token and inspector.
+// Stack limit belongs to isolate and is kept too.
+//
+// Reset can not be called inside javascript calls.
+func (c *Context) Reset() {
+	if c.Raw == nil {
+		panic(ErrContextClosed)
+	}
+	if c.depth > 0 {
+		panic(ErrResetInsideCall)
+	}
+	c.locker.Lock()
+	old := c.Raw
+	c.Raw = v8go.NewContext(append(append([]v8go.ContextOption{}, c.options...), old.Isolate())...)
+	c.nullvalue = &JsValue{raw: v8go.Null(c.Raw.Isolate()), ctx: c}
+	c.helpers = nil
+	c.rejectionMark = 0
+	c.sourceMaps = nil
+	c.sourceMapLoader = nil
+	c.wasmDisabled = false
+	c.codeGenerationFilter = nil
+	c.locker.Unlock()
+	c.interruptLocker.Lock()
+	c.interrupts = nil
+	c.interruptLocker.Unlock()
+	c.dataLocker.Lock()
+	c.data = nil
+	c.dataLocker.Unlock()
+	c.unregister(old)
+	old.Close()
+	c.untrackAllValues()
+	c.register()
+	if c.securityToken != "" {
+		c.applySecurityToken()
+	}
+	if c.inspector != nil {
+		c.inspector.contextCreated()
+	}
+	if len(c.rejectionHandlers) > 0 {
+		C.V8jsTrackRejections(c.nativeContext())
+	}
+	for _, fn := range c.setupHooks {
+		c.setup(fn)
+	}
+	for _, fn := range c.policies {
+		c.setup(fn)
+	}
+}
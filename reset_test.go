@@ -0,0 +1,56 @@
+package v8js
+
+import "testing"
+
+func TestReset(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	setups := 0
+	ctx.AddSetupHook(func(ctx *Context) {
+		setups++
+		ctx.Global().Set("setup", ctx.NewInt32(int32(setups)).Consume())
+		ctx.DisallowCodeGeneration(nil)
+	})
+	ctx.SetData(testDataKey{}, "dropped")
+	ctx.DisableWasm()
+	ctx.RunScript(`globalThis.leaked = 1`, "main.js").Release()
+	stale := ctx.NewObject()
+	interrupted := false
+	ctx.RequestInterrupt(func(ctx *Context) {
+		interrupted = true
+	})
+	raw := ctx.Isolate().Raw
+	ctx.Reset()
+	if ctx.Isolate().Raw != raw || len(ctx.Isolate().Contexts()) != 1 || ctx.GetData(testDataKey{}) != nil || len(ctx.policies) != 1 {
+		t.Fatal()
+	}
+	live := ctx.LiveValues()
+	stale.Release()
+	if ctx.LiveValues() != live {
+		t.Fatal(ctx.LiveValues(), live)
+	}
+	result := ctx.RunScript(`[typeof leaked, setup, typeof WebAssembly, (() => { try { eval("1") } catch (e) { return e.name } })()].join(",")`, "main.js")
+	defer result.Release()
+	if result.String() != "undefined,2,undefined,EvalError" || interrupted {
+		t.Fatal(result.String())
+	}
+	var rejected string
+	ctx.OnUnhandledRejection(func(reason *JsValue, promise *JsValue) {
+		rejected = reason.String()
+	})
+	ctx.RunScript(`Promise.reject("after reset")`, "main.js").Release()
+	ctx.PerformMicrotaskCheckpoint()
+	if rejected != "after reset" {
+		t.Fatal(rejected)
+	}
+	ctx.Global().Set("reset", ctx.NewFunction(func(call *FunctionCallbackInfo) *Consumed {
+		call.Context().Reset()
+		return nil
+	}).Consume())
+	err := catchJSError(func() {
+		ctx.RunScript(`reset()`, "main.js")
+	})
+	if err == nil || err.Message != ErrResetInsideCall.Error() {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,161 @@
+package v8js
+
+// DefaultSandboxRemovedGlobals globals removed by default sandbox options.
+var DefaultSandboxRemovedGlobals = []string{"SharedArrayBuffer", "Atomics", "WebAssembly"}
+
+// SandboxOptions options to harden global environment of context.
+type SandboxOptions struct {
+	// Globals allowlist of globals kept,all other globals removed.
+	// All globals are kept if nil.
+	// undefined,NaN,Infinity and globalThis are always kept.
+	// Go helpers like Export and JSON use builtins,which should be kept if those helpers used.
+	Globals []string
+	// Remove globals to remove.
+	Remove []string
+	// Freeze globals deeply frozen and made read only,such as builtin namespaces installed by host.
+	Freeze []string
+	// FreezeIntrinsics freezes built-in constructors,prototypes and namespaces like Object,Array.prototype and JSON,
+	// and makes their global bindings read only,to prevent prototype pollution between scripts.
+	// Common overridden properties like toString,constructor,name and message are kept overridable on instances.
+	FreezeIntrinsics bool
+}
+
+// NewSandboxOptions creates sandbox options with hardened preset,
+// which removes DefaultSandboxRemovedGlobals and freezes intrinsics.
+func NewSandboxOptions() *SandboxOptions {
+	return &SandboxOptions{
+		Remove:           append([]string{}, DefaultSandboxRemovedGlobals...),
+		FreezeIntrinsics: true,
+	}
+}
+
+const sandboxBootstrap = `(function(allowlist, remove, freeze, freezeIntrinsics) {
+	const g = globalThis;
+	const { Object, Function, Array, Error, Promise, Map, Set, WeakSet, Int8Array, Symbol, Reflect, TypeError, String } = g;
+	const intrinsics = [
+		"Object", "Function", "Array", "Number", "Boolean", "String", "Symbol", "BigInt", "Date", "RegExp", "Promise",
+		"Error", "AggregateError", "EvalError", "RangeError", "ReferenceError", "SyntaxError", "TypeError", "URIError",
+		"Map", "Set", "WeakMap", "WeakSet", "WeakRef", "FinalizationRegistry", "Proxy", "Reflect", "JSON", "Math", "Intl",
+		"ArrayBuffer", "SharedArrayBuffer", "DataView", "Atomics", "WebAssembly",
+		"Int8Array", "Uint8Array", "Uint8ClampedArray", "Int16Array", "Uint16Array", "Int32Array", "Uint32Array",
+		"Float32Array", "Float64Array", "BigInt64Array", "BigUint64Array",
+		"parseInt", "parseFloat", "isNaN", "isFinite", "decodeURI", "decodeURIComponent", "encodeURI", "encodeURIComponent",
+		"escape", "unescape", "eval"
+	];
+	// intrinsics captured before globals removed.
+	const captured = intrinsics.map((name) => g[name]);
+	const kept = new Set(["undefined", "NaN", "Infinity", "globalThis"]);
+	if (allowlist) {
+		for (const name of allowlist) {
+			kept.add(name);
+		}
+		for (const name of Reflect.ownKeys(g)) {
+			if (typeof name === "string" && !kept.has(name)) {
+				delete g[name];
+			}
+		}
+	}
+	for (const name of remove) {
+		delete g[name];
+	}
+	const frozen = new WeakSet();
+	const harden = (root) => {
+		const stack = [root];
+		while (stack.length) {
+			const obj = stack.pop();
+			if ((typeof obj !== "object" && typeof obj !== "function") || obj === null || frozen.has(obj)) {
+				continue;
+			}
+			frozen.add(obj);
+			Object.freeze(obj);
+			stack.push(Object.getPrototypeOf(obj));
+			for (const key of Reflect.ownKeys(obj)) {
+				const desc = Object.getOwnPropertyDescriptor(obj, key);
+				stack.push(desc.value, desc.get, desc.set);
+			}
+		}
+	};
+	const lock = (name) => {
+		const desc = Object.getOwnPropertyDescriptor(g, name);
+		if (!desc) {
+			return;
+		}
+		harden(desc.value);
+		if (desc.configurable) {
+			Object.defineProperty(g, name, { writable: false, configurable: false });
+		}
+	};
+	if (freezeIntrinsics) {
+		// Properties of frozen prototypes can not be assigned on instances,
+		// convert commonly overridden ones to accessors which define own properties.
+		const overridable = (proto, names) => {
+			for (const name of names) {
+				const desc = Object.getOwnPropertyDescriptor(proto, name);
+				if (!desc || !("value" in desc) || !desc.configurable) {
+					continue;
+				}
+				const value = desc.value;
+				Object.defineProperty(proto, name, {
+					get() {
+						return value;
+					},
+					set(v) {
+						if (this === proto) {
+							throw new TypeError("Cannot assign to read only property '" + String(name) + "' of object");
+						}
+						Object.defineProperty(this, name, { value: v, writable: true, enumerable: true, configurable: true });
+					},
+					enumerable: desc.enumerable,
+					configurable: false
+				});
+			}
+		};
+		overridable(Object.prototype, ["constructor", "toString", "valueOf", "toLocaleString", "hasOwnProperty"]);
+		overridable(Function.prototype, ["constructor", "name", "toString", "apply", "call", "bind"]);
+		overridable(Error.prototype, ["constructor", "name", "message", "toString"]);
+		overridable(Array.prototype, ["constructor", "toString", "push", "concat"]);
+		overridable(Promise.prototype, ["constructor", "then"]);
+		captured.forEach(harden);
+		for (const name of intrinsics) {
+			lock(name);
+		}
+		harden(Object.getPrototypeOf(async function() {}));
+		harden(Object.getPrototypeOf(function*() {}));
+		harden(Object.getPrototypeOf(async function*() {}));
+		harden(Object.getPrototypeOf([][Symbol.iterator]()));
+		harden(Object.getPrototypeOf(new Map()[Symbol.iterator]()));
+		harden(Object.getPrototypeOf(new Set()[Symbol.iterator]()));
+		harden(Object.getPrototypeOf(""[Symbol.iterator]()));
+		harden(Object.getPrototypeOf(/a/[Symbol.matchAll]("")));
+		harden(Object.getPrototypeOf(Int8Array));
+	}
+	for (const name of freeze) {
+		lock(name);
+	}
+})`
+
+// ApplySandbox hardens global environment of context with given options.
+// Sandbox should be applied after host globals installed and before untrusted scripts run,
+// as frozen intrinsics can not be patched anymore,such as by EnableSourceMaps or DisallowCodeGeneration.
+func (c *Context) ApplySandbox(opt *SandboxOptions) {
+	c.keepPolicy(func(ctx *Context) {
+		ctx.ApplySandbox(opt)
+	})
+	// helpers used by Go api capture builtins before they removed.
+	for name := range helperScripts {
+		c.helper(name)
+	}
+	install := c.RunScript(sandboxBootstrap, "sandbox.js")
+	defer install.Release()
+	allowlist := c.NullValue()
+	if opt.Globals != nil {
+		allowlist = c.NewStringArray(opt.Globals...)
+	}
+	install.Call(
+		c.NullValue(),
+		allowlist.Consume(),
+		c.NewStringArray(opt.Remove...).Consume(),
+		c.NewStringArray(opt.Freeze...).Consume(),
+		c.NewBoolean(opt.FreezeIntrinsics).Consume(),
+	).Release()
+}
@@ -0,0 +1,103 @@
+package v8js
+
+import "testing"
+
+func TestSandbox(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	ctx.Global().Set("host", ctx.RunScript(`({ config: { debug: false } })`, "host.js").Consume())
+	opt := NewSandboxOptions()
+	opt.Freeze = []string{"host"}
+	ctx.ApplySandbox(opt)
+	result := ctx.RunScript(`
+"use strict";
+const results = [typeof SharedArrayBuffer, typeof WebAssembly, typeof Atomics];
+const fails = (fn) => { try { fn(); return false } catch (e) { return e instanceof TypeError } };
+results.push(fails(() => { Object.prototype.polluted = 1 }));
+results.push(fails(() => { Array.prototype.map = null }));
+results.push(fails(() => { Array = null }));
+results.push(fails(() => { host.config.debug = true }));
+results.push(fails(() => { Object.getPrototypeOf([][Symbol.iterator]()).next = null }));
+results.push(fails(() => { Object.prototype.toString = null }));
+const obj = {};
+obj.toString = () => "custom";
+class MyError extends Error {}
+const err = new MyError("failed");
+err.name = "MyError";
+results.push(String(obj), String(err), [1, 2].map((x) => x * 2).join(","));
+results.join(" ")`, "main.js")
+	defer result.Release()
+	if result.String() != "undefined undefined undefined true true true true true true custom MyError: failed 2,4" {
+		t.Fatal(result.String())
+	}
+}
+
+func TestSandboxAllowlist(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	ctx.ApplySandbox(&SandboxOptions{Globals: []string{"Object", "JSON"}})
+	result := ctx.RunScript(`[typeof Object, typeof JSON, typeof Array, typeof Math, typeof undefined].join(" ")`, "main.js")
+	defer result.Release()
+	if result.String() != "function object undefined undefined undefined" {
+		t.Fatal(result.String())
+	}
+}
+
+func TestSandboxAllowlistFrozen(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	ctx.ApplySandbox(&SandboxOptions{Globals: []string{"Object"}, FreezeIntrinsics: true})
+	arr := ctx.NewStringArray("a", "b")
+	defer arr.Release()
+	if arr.GetIdx(1).String() != "b" {
+		t.Fatal()
+	}
+	result := ctx.RunScript(`Object.isFrozen(Object.prototype) && Object.isFrozen([].map)`, "main.js")
+	defer result.Release()
+	if !result.Boolean() {
+		t.Fatal()
+	}
+}
+
+func TestSandboxHelpers(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	other := ctx.isolate.NewContext()
+	defer other.Close()
+	obj := ctx.RunScript(`({ list: [1, new Uint8Array([2])], fn: function f() {} })`, "main.js")
+	defer obj.Release()
+	ctx.ApplySandbox(&SandboxOptions{Globals: []string{}})
+	toJSON := func(v *JsValue) string {
+		data, err := v.JSON(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if obj.TypeOf() != "object" || toJSON(obj) != `{"list":[1,{"0":2}]}` {
+		t.Fatal(toJSON(obj))
+	}
+	fn := obj.Get("fn")
+	defer fn.Release()
+	if fn.String() != "function f() {}" || !fn.IsConstructor() {
+		t.Fatal(fn.String())
+	}
+	data, err := ctx.NewArrayBuffer([]byte{1, 2}).Export()
+	if err != nil || len(data.([]byte)) != 2 {
+		t.Fatal(data, err)
+	}
+	list := obj.Get("list")
+	defer list.Release()
+	exported, err := list.Export()
+	if err != nil || len(exported.([]interface{})) != 2 {
+		t.Fatal(exported, err)
+	}
+	cloned, err := list.CloneTo(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cloned.Release()
+	if toJSON(cloned) != `[1,{"0":2}]` {
+		t.Fatal(toJSON(cloned))
+	}
+}
@@ -0,0 +1,272 @@
+package v8js
+
+// #include <stdlib.h>
+// #include "v8js.h"
+import "C"
+import (
+	"errors"
+	"runtime"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+)
+
+var ErrHostObjectNotCloned = errors.New("v8js: host object could not be cloned")
+var ErrTransferredBufferUsed = errors.New("v8js: transferred buffer already used")
+
+// SerializeOptions options of JsValue.SerializeWithOptions.
+type SerializeOptions struct {
+	// Transfer ArrayBuffers written as transfer index instead of their content.
+	// Transferred buffers are detached after serialized,same as transfer list of postMessage,
+	// and their memory is moved to Transferred without copying.
+	Transfer []*JsValue
+	// Transferred memory of buffers in Transfer in same order,set after serialized.
+	// It should be passed to DeserializeOptions.Transferred.
+	Transferred []*TransferredBuffer
+	// HostObject hook called with host objects created by Context.NewHostObject.
+	// Returns nil to report value can not be cloned.
+	HostObject func(v *JsValue) ([]byte, error)
+}
+
+// NewSerializeOptions creates new serialize options.
+func NewSerializeOptions() *SerializeOptions {
+	return &SerializeOptions{}
+}
+
+// DeserializeOptions options of Context.DeserializeWithOptions.
+type DeserializeOptions struct {
+	// Transferred memory of buffers transferred by serializer,in same order as SerializeOptions.Transferred.
+	// Each buffer becomes memory of a new ArrayBuffer,and is released after deserialized.
+	Transferred []*TransferredBuffer
+	// HostObject hook to create host object from data returned by SerializeOptions.HostObject.
+	// Returned value should be an object,and will be released after deserialized.
+	HostObject func(ctx *Context, data []byte) (*JsValue, error)
+}
+
+// NewDeserializeOptions creates new deserialize options.
+func NewDeserializeOptions() *DeserializeOptions {
+	return &DeserializeOptions{}
+}
+
+// TransferredBuffer memory of ArrayBuffer transferred by serializer.
+// It can be deserialized once in any context,or released by Release.
+type TransferredBuffer struct {
+	locker sync.Mutex
+	data   []byte
+	shared C.V8jsSharedPtr
+}
+
+// Bytes returns transferred memory.
+// Slice must not be used after buffer deserialized or released.
+func (b *TransferredBuffer) Bytes() []byte {
+	return b.data
+}
+
+// Release releases transferred memory if it is not deserialized.
+func (b *TransferredBuffer) Release() {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	if b.shared != nil {
+		C.V8jsSharedRelease(b.shared)
+		b.shared = nil
+		b.data = nil
+	}
+}
+
+// take takes native memory from buffer,which will be released by caller.
+func (b *TransferredBuffer) take() C.V8jsSharedPtr {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	shared := b.shared
+	b.shared = nil
+	b.data = nil
+	return shared
+}
+
+// NewHostObject creates a new empty host object.
+// Host objects are written by SerializeOptions.HostObject hook,and can not be serialized without hook.
+func (c *Context) NewHostObject() *JsValue {
+	return c.wrapNative(C.V8jsNewHostObject(c.nativeContext()))
+}
+
+// serializeHooks host object hooks called by native serializer.
+type serializeHooks struct {
+	ctx    *Context
+	write  func(v *JsValue) ([]byte, error)
+	read   func(ctx *Context, data []byte) (*JsValue, error)
+	values []*JsValue
+	err    error
+}
+
+// handle returns cgo handle of hooks,or 0 if no hook set.
+// Returned function deletes the handle.
+func (h *serializeHooks) handle() (C.uintptr_t, func()) {
+	if h.write == nil && h.read == nil {
+		return 0, func() {}
+	}
+	handle := cgo.NewHandle(h)
+	return C.uintptr_t(handle), handle.Delete
+}
+
+// fail records first error returned by hooks,and returns error message for native serializer.
+func (h *serializeHooks) fail(err error) *C.char {
+	if h.err == nil {
+		h.err = err
+	}
+	return C.CString(err.Error())
+}
+
+func (h *serializeHooks) release() {
+	for _, v := range h.values {
+		v.Release()
+	}
+}
+
+//export v8jsWriteHostObject
+func v8jsWriteHostObject(handle C.uintptr_t, value C.V8jsValuePtr) (result C.V8jsHostData) {
+	h := cgo.Handle(handle).Value().(*serializeHooks)
+	v := h.ctx.wrapNative(value)
+	defer v.Release()
+	defer func() {
+		if r := recover(); r != nil {
+			result = C.V8jsHostData{error: h.fail(toError(r))}
+		}
+	}()
+	data, err := h.write(v)
+	if err == nil && data == nil {
+		err = ErrHostObjectNotCloned
+	}
+	if err != nil {
+		return C.V8jsHostData{error: h.fail(err)}
+	}
+	return C.V8jsHostData{data: C.CBytes(data), length: C.size_t(len(data))}
+}
+
+//export v8jsReadHostObject
+func v8jsReadHostObject(handle C.uintptr_t, data unsafe.Pointer, length C.size_t) (result C.V8jsHostObject) {
+	h := cgo.Handle(handle).Value().(*serializeHooks)
+	defer func() {
+		if r := recover(); r != nil {
+			result = C.V8jsHostObject{error: h.fail(toError(r))}
+		}
+	}()
+	v, err := h.read(h.ctx, C.GoBytes(data, C.int(length)))
+	if err == nil && v == nil {
+		err = ErrHostObjectNotCloned
+	}
+	if err != nil {
+		return C.V8jsHostObject{error: h.fail(err)}
+	}
+	h.values = append(h.values, v)
+	return C.V8jsHostObject{value: v.native()}
+}
+
+// nativeValues returns native pointers of values.
+func nativeValues(values []*JsValue) (*C.V8jsValuePtr, C.int) {
+	if len(values) == 0 {
+		return nil, 0
+	}
+	list := make([]C.V8jsValuePtr, len(values))
+	for i, v := range values {
+		list[i] = v.native()
+	}
+	return &list[0], C.int(len(list))
+}
+
+// Serialize serializes value with v8 ValueSerializer,which implements structured clone algorithm.
+// Supports primitives,plain objects,arrays,Map,Set,Date,RegExp,BigInt,errors,ArrayBuffer,typed arrays and cyclic references.
+// Serialized data can be deserialized in any context by Context.Deserialize.
+func (v *JsValue) Serialize() ([]byte, error) {
+	return v.SerializeWithOptions(nil)
+}
+
+// SerializeWithOptions serializes value with given options.
+func (v *JsValue) SerializeWithOptions(opt *SerializeOptions) (result []byte, err error) {
+	if opt == nil {
+		opt = NewSerializeOptions()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+	c := v.ctx
+	hooks := &serializeHooks{ctx: c, write: opt.HostObject}
+	handle, free := hooks.handle()
+	defer free()
+	transfer, length := nativeValues(opt.Transfer)
+	// one more slot so address of first slot is valid if nothing transferred.
+	transferred := make([]C.V8jsShared, len(opt.Transfer)+1)
+	c.enter()
+	defer c.exit()
+	rtn := C.V8jsSerialize(c.nativeContext(), v.native(), transfer, length, &transferred[0], handle)
+	runtime.KeepAlive(v)
+	runtime.KeepAlive(opt.Transfer)
+	opt.Transferred = nil
+	for _, shared := range transferred[:len(opt.Transfer)] {
+		if shared.shared != nil {
+			opt.Transferred = append(opt.Transferred, &TransferredBuffer{
+				data:   unsafe.Slice((*byte)(shared.data), int(shared.length)),
+				shared: shared.shared,
+			})
+		}
+	}
+	err = c.leave(nil, nativeError(rtn.error))
+	if hooks.err != nil {
+		err = hooks.err
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer C.free(rtn.data)
+	return C.GoBytes(rtn.data, C.int(rtn.length)), nil
+}
+
+// Deserialize deserializes value serialized by JsValue.Serialize.
+func (c *Context) Deserialize(data []byte) (*JsValue, error) {
+	return c.DeserializeWithOptions(data, nil)
+}
+
+// DeserializeWithOptions deserializes value with given options.
+func (c *Context) DeserializeWithOptions(data []byte, opt *DeserializeOptions) (result *JsValue, err error) {
+	if opt == nil {
+		opt = NewDeserializeOptions()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+	hooks := &serializeHooks{ctx: c, read: opt.HostObject}
+	defer hooks.release()
+	handle, free := hooks.handle()
+	defer free()
+	transferred := make([]C.V8jsSharedPtr, len(opt.Transferred)+1)
+	for i, b := range opt.Transferred {
+		transferred[i] = b.take()
+		if transferred[i] == nil {
+			return nil, ErrTransferredBufferUsed
+		}
+		defer C.V8jsSharedRelease(transferred[i])
+	}
+	source := C.CBytes(data)
+	defer C.free(source)
+	c.enter()
+	defer c.exit()
+	rtn := C.V8jsDeserialize(c.nativeContext(), source, C.size_t(len(data)), &transferred[0], C.int(len(opt.Transferred)), handle)
+	err = nativeError(rtn.error)
+	if err == nil {
+		result = c.wrapNative(rtn.value)
+	}
+	err = c.leave(result, err)
+	if hooks.err != nil {
+		err = hooks.err
+	}
+	if err != nil {
+		if result != nil {
+			result.Release()
+		}
+		return nil, err
+	}
+	return result, nil
+}
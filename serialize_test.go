@@ -0,0 +1,132 @@
+package v8js
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSerialize(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	value := ctx.RunScript(`
+const buf = new ArrayBuffer(8);
+const obj = {
+	str: "hello 😀",
+	num: -1.5,
+	big: -12345678901234567890n,
+	list: [1, , undefined, null, true],
+	date: new Date(1000),
+	re: /a+/gi,
+	map: new Map([[{ k: 1 }, "v"]]),
+	set: new Set(["a", 2]),
+	bytes: new Uint16Array(buf, 2, 3),
+	view: new DataView(buf),
+	err: new RangeError("range"),
+	boxed: new String("boxed")
+};
+obj.self = obj;
+new Uint16Array(buf)[1] = 7;
+obj`, "main.js")
+	defer value.Release()
+	data, err := value.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := NewContext()
+	defer target.Close()
+	result, err := target.Deserialize(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target.Global().Set("obj", result.Consume())
+	check := target.RunScript(`[
+		obj.str === "hello 😀",
+		obj.num === -1.5,
+		obj.big === -12345678901234567890n,
+		obj.list.length === 5 && !(1 in obj.list) && 2 in obj.list && obj.list[3] === null && obj.list[4] === true,
+		obj.date.getTime() === 1000,
+		obj.re.source === "a+" && obj.re.flags === "gi",
+		[...obj.map.keys()][0].k === 1 && [...obj.map.values()][0] === "v",
+		obj.set.has("a") && obj.set.has(2),
+		obj.bytes instanceof Uint16Array && obj.bytes.length === 3 && obj.bytes[0] === 7,
+		obj.bytes.buffer === obj.view.buffer,
+		obj.err instanceof RangeError && obj.err.message === "range" && obj.err.stack.startsWith("RangeError: range"),
+		obj.boxed instanceof String && obj.boxed.valueOf() === "boxed",
+		obj.self === obj
+	].every((v) => v === true)`, "main.js")
+	defer check.Release()
+	if !check.Boolean() {
+		t.Fatal()
+	}
+}
+
+func TestSerializeTransferAndHost(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	host := ctx.NewHostObject()
+	host.Set("name", ctx.NewString("hello").Consume())
+	ctx.Global().Set("host", host.Consume())
+	value := ctx.RunScript(`[new Uint8Array([1, 2, 3]).buffer, host]`, "main.js")
+	defer value.Release()
+	if _, err := value.Serialize(); err == nil {
+		t.Fatal()
+	}
+	buf := value.GetIdx(0)
+	defer buf.Release()
+	opt := NewSerializeOptions()
+	opt.Transfer = []*JsValue{buf}
+	opt.HostObject = func(v *JsValue) ([]byte, error) {
+		return []byte(v.Get("name").String()), nil
+	}
+	data, err := value.SerializeWithOptions(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte{1, 2, 3}) {
+		t.Fatal("transferred buffer should not be written")
+	}
+	if len(buf.ArrayBufferContent()) != 0 {
+		t.Fatal("transferred buffer should be detached")
+	}
+	if len(opt.Transferred) != 1 || !bytes.Equal(opt.Transferred[0].Bytes(), []byte{1, 2, 3}) {
+		t.Fatal(opt.Transferred)
+	}
+	if _, err := ctx.Deserialize(data); err == nil {
+		t.Fatal()
+	}
+	dopt := NewDeserializeOptions()
+	dopt.Transferred = opt.Transferred
+	dopt.HostObject = func(ctx *Context, data []byte) (*JsValue, error) {
+		v := ctx.NewObject()
+		v.Set("name", ctx.NewString("host:"+string(data)).Consume())
+		return v, nil
+	}
+	result, err := ctx.DeserializeWithOptions(data, dopt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Release()
+	if !bytes.Equal(result.GetIdx(0).ArrayBufferContent(), []byte{1, 2, 3}) || result.GetIdx(1).Get("name").String() != "host:hello" {
+		t.Fatal()
+	}
+	if _, err := ctx.DeserializeWithOptions(data, dopt); err != ErrTransferredBufferUsed {
+		t.Fatal(err)
+	}
+	fn := ctx.RunScript(`[function hello() {}]`, "main.js")
+	defer fn.Release()
+	if _, err := fn.SerializeWithOptions(opt); err == nil {
+		t.Fatal("functions should not be passed to host object hook")
+	}
+	opt = NewSerializeOptions()
+	opt.HostObject = func(v *JsValue) ([]byte, error) {
+		return nil, ErrHostObjectNotCloned
+	}
+	hosts := ctx.RunScript(`[host]`, "main.js")
+	defer hosts.Release()
+	if _, err := hosts.SerializeWithOptions(opt); err != ErrHostObjectNotCloned {
+		t.Fatal(err)
+	}
+	if _, err := ctx.Deserialize([]byte("broken")); err == nil {
+		t.Fatal()
+	}
+}
@@ -0,0 +1,201 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+var ErrSharedBufferReleased = errors.New("v8js: shared buffer released")
+var ErrSharedBufferIndex = errors.New("v8js: shared buffer index out of range")
+var ErrNotSharedArrayBuffer = errors.New("v8js: value is not a SharedArrayBuffer")
+var ErrSharedBufferWait = errors.New("v8js: shared buffer wait failed")
+
+// Results of SharedBuffer.Wait,same as results of javascript Atomics.wait.
+const (
+	WaitOK       = "ok"
+	WaitNotEqual = "not-equal"
+	WaitTimedOut = "timed-out"
+)
+
+var waitResults = []string{WaitOK, WaitNotEqual, WaitTimedOut}
+
+// SharedBuffer shared memory accessed by Go without copying,and exposed to javascript as SharedArrayBuffer.
+//
+// Memory created by NewSharedBuffer is allocated by Go with C allocator,as memory of Go heap can not be retained by v8.
+// Memory is freed after Release called and all SharedArrayBuffer values using it collected,
+// so it can be exposed to contexts of any isolate,and javascript keeps using it after buffer released.
+//
+// Int32 methods are atomic and interoperate with javascript Atomics on Int32Array of the buffer.
+type SharedBuffer struct {
+	// locker is held for reading while memory used,so Release waits for running atomic operations.
+	locker sync.RWMutex
+	value  *JsValue
+	data   []byte
+	shared C.V8jsSharedPtr
+}
+
+func newSharedBuffer(shared C.V8jsShared) *SharedBuffer {
+	return &SharedBuffer{
+		data:   unsafe.Slice((*byte)(shared.data), int(shared.length)),
+		shared: shared.shared,
+	}
+}
+
+// Value returns SharedArrayBuffer value of buffer in context which created it.
+func (b *SharedBuffer) Value() *JsValue {
+	return b.value
+}
+
+// Bytes returns memory of buffer.
+// Slice must not be used after buffer released.
+func (b *SharedBuffer) Bytes() []byte {
+	return b.data
+}
+
+// Len returns byte length of buffer.
+func (b *SharedBuffer) Len() int {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+	return len(b.data)
+}
+
+// Expose returns SharedArrayBuffer value sharing buffer memory in given context.
+// Context can belong to any isolate.
+func (b *SharedBuffer) Expose(ctx *Context) (*JsValue, error) {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+	if b.shared == nil {
+		return nil, ErrSharedBufferReleased
+	}
+	return ctx.wrapNative(C.V8jsSharedExpose(ctx.nativeContext(), b.shared)), nil
+}
+
+// Release releases buffer memory held by Go and buffer value.
+// Waiters blocked in Wait keep memory alive until they return.
+func (b *SharedBuffer) Release() {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	if b.shared == nil {
+		return
+	}
+	C.V8jsSharedRelease(b.shared)
+	b.shared = nil
+	b.data = nil
+	if b.value != nil {
+		b.value.Release()
+	}
+}
+
+// copyShared checks index and returns a new native reference to buffer memory,which should be released after used.
+func (b *SharedBuffer) copyShared(index int) C.V8jsSharedPtr {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+	b.int32At(index)
+	return C.V8jsSharedCopy(b.shared)
+}
+
+// int32At returns pointer to int32 at given index,locker should be held for reading while pointer used.
+func (b *SharedBuffer) int32At(index int) *int32 {
+	if b.data == nil {
+		panic(ErrSharedBufferReleased)
+	}
+	if index < 0 || index*4+4 > len(b.data) {
+		panic(ErrSharedBufferIndex)
+	}
+	return (*int32)(unsafe.Pointer(&b.data[index*4]))
+}
+
+// LoadInt32 atomically loads int32 at given index of buffer as Int32Array.
+func (b *SharedBuffer) LoadInt32(index int) int32 {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+	return atomic.LoadInt32(b.int32At(index))
+}
+
+// StoreInt32 atomically stores int32 at given index of buffer as Int32Array.
+func (b *SharedBuffer) StoreInt32(index int, value int32) {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+	atomic.StoreInt32(b.int32At(index), value)
+}
+
+// AddInt32 atomically adds delta to int32 at given index of buffer as Int32Array,and returns new value.
+func (b *SharedBuffer) AddInt32(index int, delta int32) int32 {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+	return atomic.AddInt32(b.int32At(index), delta)
+}
+
+// CompareAndSwapInt32 atomically executes compare-and-swap on int32 at given index of buffer as Int32Array.
+func (b *SharedBuffer) CompareAndSwapInt32(index int, old int32, new int32) bool {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+	return atomic.CompareAndSwapInt32(b.int32At(index), old, new)
+}
+
+// Wait blocks until int32 at given index of buffer as Int32Array notified,
+// or timeout elapsed,same as javascript Atomics.wait.
+// Timeout less than 0 means no timeout.
+// Returns WaitNotEqual if value not equal to given value when called.
+//
+// Go waiters are woken by Notify and by javascript Atomics.notify of any isolate.
+// Each Go waiter blocks a thread while waiting.
+func (b *SharedBuffer) Wait(index int, value int32, timeout time.Duration) string {
+	shared := b.copyShared(index)
+	defer C.V8jsSharedRelease(shared)
+	// waiter isolates may be created.
+	startEngine()
+	ms := math.Inf(1)
+	if timeout >= 0 {
+		ms = float64(timeout) / float64(time.Millisecond)
+	}
+	result := C.V8jsSharedWait(shared, C.int(index), C.int32_t(value), C.double(ms))
+	runtime.KeepAlive(b)
+	if result < 0 {
+		panic(ErrSharedBufferWait)
+	}
+	return waitResults[result]
+}
+
+// Notify wakes up to count waiters waiting on given index,same as javascript Atomics.notify.
+// Both Go waiters blocked in Wait and javascript waiters blocked in Atomics.wait of any isolate are woken.
+// All waiters are woken if count less than 0.
+// Returns count of woken waiters.
+func (b *SharedBuffer) Notify(index int, count int) int {
+	shared := b.copyShared(index)
+	defer C.V8jsSharedRelease(shared)
+	startEngine()
+	n := math.Inf(1)
+	if count >= 0 {
+		n = float64(count)
+	}
+	return int(C.V8jsSharedNotify(shared, C.int(index), C.double(n)))
+}
+
+// SharedBufferFromValue creates SharedBuffer from SharedArrayBuffer value.
+// Value will be released when buffer released.
+func SharedBufferFromValue(v *JsValue) (*SharedBuffer, error) {
+	shared := C.V8jsSharedFromValue(v.ctx.nativeContext(), v.native())
+	runtime.KeepAlive(v)
+	if shared.shared == nil {
+		return nil, ErrNotSharedArrayBuffer
+	}
+	b := newSharedBuffer(shared)
+	b.value = v
+	return b, nil
+}
+
+// NewSharedBuffer creates shared memory with given byte length,and returns it as SharedBuffer
+// with SharedArrayBuffer value in context.
+func (c *Context) NewSharedBuffer(length int) *SharedBuffer {
+	b := newSharedBuffer(C.V8jsNewShared(C.size_t(length)))
+	b.value = c.wrapNative(C.V8jsSharedExpose(c.nativeContext(), b.shared))
+	return b
+}
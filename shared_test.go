@@ -0,0 +1,160 @@
+package v8js
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSharedBuffer(t *testing.T) {
+	iso := NewIsolate()
+	defer iso.Dispose()
+	ctx := iso.NewContext()
+	ctx.ApplySandbox(NewSandboxOptions())
+	other := iso.NewContext()
+	b := ctx.NewSharedBuffer(16)
+	defer b.Release()
+	if b.Len() != 16 {
+		t.Fatal(b.Len())
+	}
+	ctx.Global().Set("shared", b.Value().ConsumeReuseble().Consume())
+	exposed, err := b.Expose(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.Global().Set("shared", exposed.Consume())
+	other.RunScript(`Atomics.store(new Int32Array(shared), 0, 5)`, "main.js").Release()
+	if b.LoadInt32(0) != 5 {
+		t.Fatal(b.LoadInt32(0))
+	}
+	b.StoreInt32(1, 7)
+	b.Bytes()[8] = 9
+	result := other.RunScript(`[Atomics.load(new Int32Array(shared), 1), new Uint8Array(shared)[8]].join(",")`, "main.js")
+	defer result.Release()
+	if result.String() != "7,9" {
+		t.Fatal(result.String())
+	}
+	if b.AddInt32(0, 2) != 7 || !b.CompareAndSwapInt32(0, 7, 8) || b.LoadInt32(0) != 8 {
+		t.Fatal()
+	}
+	if b.Wait(0, 1, 0) != WaitNotEqual || b.Wait(0, 8, time.Millisecond) != WaitTimedOut {
+		t.Fatal()
+	}
+	done := make(chan string)
+	go func() {
+		done <- b.Wait(2, 9, -1)
+	}()
+	for b.Notify(2, 1) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if <-done != WaitOK {
+		t.Fatal()
+	}
+	go func() {
+		done <- b.Wait(3, 0, 5*time.Second)
+	}()
+	notified := func(ctx *Context, index int) bool {
+		v := ctx.RunScript(fmt.Sprintf(`Atomics.notify(new Int32Array(shared), %d)`, index), "main.js")
+		defer v.Release()
+		return v.Int32() == 1
+	}
+	for !notified(other, 3) {
+		time.Sleep(time.Millisecond)
+	}
+	if <-done != WaitOK {
+		t.Fatal()
+	}
+	remote := NewContext()
+	defer remote.Close()
+	exposed, err = b.Expose(remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote.Global().Set("shared", exposed.Consume())
+	go func() {
+		v := remote.RunScript(`Atomics.wait(new Int32Array(shared), 3, 0, 5000)`, "main.js")
+		result := v.String()
+		v.Release()
+		done <- result
+	}()
+	for b.Notify(3, 1) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if <-done != WaitOK {
+		t.Fatal()
+	}
+}
+
+func TestSharedBufferRelease(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	b := ctx.NewSharedBuffer(8)
+	ctx.Global().Set("shared", b.Value().ConsumeReuseble().Consume())
+	done := make(chan string)
+	go func() {
+		done <- b.Wait(0, 0, -1)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	b.Release()
+	v := ctx.RunScript(`const view = new Int32Array(shared); Atomics.store(view, 0, 1); Atomics.notify(view, 0)`, "main.js")
+	defer v.Release()
+	if v.Int32() != 1 || <-done != WaitOK {
+		t.Fatal(v.Int32())
+	}
+	defer func() {
+		if r := recover(); r != ErrSharedBufferReleased {
+			t.Fatal(r)
+		}
+	}()
+	b.Notify(0, 1)
+}
+
+func TestSharedBufferWaiters(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	b := ctx.NewSharedBuffer(8)
+	defer b.Release()
+	// more concurrent waiters than idle waiters kept,so released waiters are disposed.
+	for round := 0; round < 2; round++ {
+		done := make(chan string)
+		for i := 0; i < 8; i++ {
+			go func() {
+				done <- b.Wait(0, 0, 5*time.Second)
+			}()
+		}
+		woken := 0
+		for woken < 8 {
+			woken += b.Notify(0, -1)
+			time.Sleep(time.Millisecond)
+		}
+		for i := 0; i < 8; i++ {
+			if <-done != WaitOK {
+				t.Fatal(round)
+			}
+		}
+	}
+}
+
+func TestSharedBufferReleaseConcurrently(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	b := ctx.NewSharedBuffer(8)
+	done := make(chan interface{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer func() {
+				done <- recover()
+			}()
+			for {
+				b.AddInt32(1, 1)
+			}
+		}()
+	}
+	time.Sleep(time.Millisecond)
+	b.Release()
+	for i := 0; i < 4; i++ {
+		if r := <-done; r != ErrSharedBufferReleased {
+			t.Fatal(r)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package v8js
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const base64VLQChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+var base64VLQValues = func() map[byte]int {
+	result := make(map[byte]int, len(base64VLQChars))
+	for i := range base64VLQChars {
+		result[base64VLQChars[i]] = i
+	}
+	return result
+}()
+
+var ErrInvalidSourceMap = errors.New("v8js: invalid source map")
+
+// SourcePosition position in original source.
+type SourcePosition struct {
+	Source string
+	// Line 1-based line number.
+	Line int
+	// Column 1-based column number.
+	Column int
+	Name   string
+}
+
+type sourceMapSegment struct {
+	column       int
+	source       int
+	sourceLine   int
+	sourceColumn int
+	name         int
+}
+
+// SourceMap parsed source map in revision 3 format.
+type SourceMap struct {
+	Sources []string
+	Names   []string
+	lines   [][]sourceMapSegment
+}
+
+type sourceMapData struct {
+	Version    int      `json:"version"`
+	SourceRoot string   `json:"sourceRoot"`
+	Sources    []string `json:"sources"`
+	Names      []string `json:"names"`
+	Mappings   string   `json:"mappings"`
+}
+
+func decodeVLQ(s string, pos int) (int, int, error) {
+	var result, shift int
+	for {
+		if pos >= len(s) {
+			return 0, pos, ErrInvalidSourceMap
+		}
+		digit, ok := base64VLQValues[s[pos]]
+		if !ok {
+			return 0, pos, ErrInvalidSourceMap
+		}
+		pos++
+		result += (digit & 31) << shift
+		if digit&32 == 0 {
+			break
+		}
+		shift += 5
+	}
+	if result&1 == 1 {
+		return -(result >> 1), pos, nil
+	}
+	return result >> 1, pos, nil
+}
+
+// ParseSourceMap parses source map data.
+func ParseSourceMap(data []byte) (*SourceMap, error) {
+	raw := &sourceMapData{}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return nil, err
+	}
+	if raw.Version != 3 {
+		return nil, ErrInvalidSourceMap
+	}
+	m := &SourceMap{
+		Sources: make([]string, len(raw.Sources)),
+		Names:   raw.Names,
+	}
+	for i, source := range raw.Sources {
+		if raw.SourceRoot != "" {
+			source = strings.TrimSuffix(raw.SourceRoot, "/") + "/" + source
+		}
+		m.Sources[i] = source
+	}
+	var source, sourceLine, sourceColumn, name int
+	for _, line := range strings.Split(raw.Mappings, ";") {
+		segments := []sourceMapSegment{}
+		column := 0
+		for _, field := range strings.Split(line, ",") {
+			if field == "" {
+				continue
+			}
+			values := make([]int, 0, 5)
+			for pos := 0; pos < len(field); {
+				var v int
+				v, pos, err = decodeVLQ(field, pos)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+			column += values[0]
+			segment := sourceMapSegment{column: column, source: -1, name: -1}
+			if len(values) >= 4 {
+				source += values[1]
+				sourceLine += values[2]
+				sourceColumn += values[3]
+				segment.source, segment.sourceLine, segment.sourceColumn = source, sourceLine, sourceColumn
+			}
+			if len(values) >= 5 {
+				name += values[4]
+				segment.name = name
+			}
+			segments = append(segments, segment)
+		}
+		sort.SliceStable(segments, func(i, j int) bool {
+			return segments[i].column < segments[j].column
+		})
+		m.lines = append(m.lines, segments)
+	}
+	return m, nil
+}
+
+// Lookup finds original position of given 1-based generated line and column.
+// Return nil if position is not mapped.
+func (m *SourceMap) Lookup(line int, column int) *SourcePosition {
+	if line < 1 || line > len(m.lines) {
+		return nil
+	}
+	segments := m.lines[line-1]
+	idx := sort.Search(len(segments), func(i int) bool {
+		return segments[i].column > column-1
+	}) - 1
+	if idx < 0 {
+		return nil
+	}
+	segment := segments[idx]
+	if segment.source < 0 || segment.source >= len(m.Sources) {
+		return nil
+	}
+	pos := &SourcePosition{
+		Source: m.Sources[segment.source],
+		Line:   segment.sourceLine + 1,
+		Column: segment.sourceColumn + 1,
+	}
+	if segment.name >= 0 && segment.name < len(m.Names) {
+		pos.Name = m.Names[segment.name]
+	}
+	return pos
+}
+
+// SourceMapLoader loads source map data of given url,referenced by script with given name.
+type SourceMapLoader func(script string, url string) ([]byte, error)
+
+// sourceMapStackHelper rewrites stack traces with positions mapped by mapFrame,
+// builtins are captured when installed.
+const sourceMapStackHelper = `(function(mapFrame) {
+	const { String } = globalThis;
+	Error.prepareStackTrace = function(error, callsites) {
+		let header;
+		try {
+			header = String(error);
+		} catch (e) {
+			header = "<error>";
+		}
+		const lines = [header];
+		for (let i = 0; i < callsites.length; i++) {
+			const site = callsites[i];
+			let line = String(site);
+			const file = site.getFileName();
+			if (file) {
+				const location = file + ":" + site.getLineNumber() + ":" + site.getColumnNumber();
+				const mapped = mapFrame(location);
+				if (mapped) {
+					line = line.replace(location, mapped);
+				}
+			}
+			lines.push("    at " + line);
+		}
+		return lines.join("\n");
+	};
+})`
+
+// EnableSourceMaps enables source map support in context.
+// Source maps referenced by "//# sourceMappingURL=" comment in scripts run by RunScript will be loaded,
+// inline data urls are decoded directly and other urls are loaded by given loader.
+// Loader can be nil if only inline source maps used.
+//
+// Error.stack and JSError returned to Go will be rewritten to original positions.
+func (c *Context) EnableSourceMaps(loader SourceMapLoader) {
+	c.keepPolicy(func(ctx *Context) {
+		ctx.EnableSourceMaps(loader)
+	})
+	c.sourceMapLoader = loader
+	if c.sourceMaps != nil {
+		return
+	}
+	c.sourceMaps = map[string]*SourceMap{}
+	install := c.RunScript(sourceMapStackHelper, "sourcemap.js")
+	defer install.Release()
+	install.Call(c.NullValue(), c.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		mapped := c.mapLocation(info.GetArg(0).String())
+		if mapped == "" {
+			return nil
+		}
+		return info.Context().NewString(mapped).Consume()
+	}).Consume()).Release()
+}
+
+// RegisterSourceMap registers source map for script with given name.
+// Source maps will be enabled if not enabled yet.
+func (c *Context) RegisterSourceMap(script string, m *SourceMap) {
+	if c.sourceMaps == nil {
+		c.EnableSourceMaps(nil)
+	}
+	c.sourceMaps[script] = m
+}
+
+func findSourceMappingURL(script string) string {
+	idx := strings.LastIndex(script, "sourceMappingURL=")
+	if idx < 4 {
+		return ""
+	}
+	prefix := strings.TrimRight(script[:idx], " \t")
+	if !strings.HasSuffix(prefix, "//#") && !strings.HasSuffix(prefix, "//@") {
+		return ""
+	}
+	value := script[idx+len("sourceMappingURL="):]
+	if end := strings.IndexAny(value, " \t\r\n"); end >= 0 {
+		value = value[:end]
+	}
+	return value
+}
+
+func decodeDataURL(u string) ([]byte, error) {
+	comma := strings.Index(u, ",")
+	if !strings.HasPrefix(u, "data:") || comma < 0 {
+		return nil, ErrInvalidSourceMap
+	}
+	if strings.HasSuffix(u[:comma], ";base64") {
+		return base64.StdEncoding.DecodeString(u[comma+1:])
+	}
+	data, err := url.PathUnescape(u[comma+1:])
+	return []byte(data), err
+}
+
+// loadSourceMap loads source map with given url,or referenced by script if url is empty.
+// Scripts with missing or broken source maps run with generated positions.
+func (c *Context) loadSourceMap(script string, name string, u string) {
+	if c.sourceMaps == nil || name == "" {
+		return
+	}
+	if u == "" {
+		u = findSourceMappingURL(script)
+	}
+	if u == "" {
+		return
+	}
+	var data []byte
+	var err error
+	if strings.HasPrefix(u, "data:") {
+		data, err = decodeDataURL(u)
+	} else if c.sourceMapLoader != nil {
+		data, err = c.sourceMapLoader(name, u)
+	} else {
+		return
+	}
+	if err != nil {
+		return
+	}
+	m, err := ParseSourceMap(data)
+	if err != nil {
+		return
+	}
+	c.sourceMaps[name] = m
+}
+
+// mapLocation maps location in "script:line:column" format to original position.
+// Return empty string if location is not mapped.
+func (c *Context) mapLocation(location string) string {
+	if len(c.sourceMaps) == 0 {
+		return ""
+	}
+	frame := &StackFrame{}
+	parseStackLocation(frame, location)
+	m := c.sourceMaps[frame.Script]
+	if m == nil || frame.Line == 0 {
+		return ""
+	}
+	pos := m.Lookup(frame.Line, frame.Column)
+	if pos == nil {
+		return ""
+	}
+	return pos.Source + ":" + strconv.Itoa(pos.Line) + ":" + strconv.Itoa(pos.Column)
+}
+
+// mapJSError rewrites location of error to original position.
+// Stack trace is rewritten by Error.prepareStackTrace already.
+func (c *Context) mapJSError(e *JSError) {
+	if mapped := c.mapLocation(e.Location); mapped != "" {
+		e.Location = mapped
+	}
+}
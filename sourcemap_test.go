@@ -0,0 +1,111 @@
+package v8js
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func encodeVLQ(values ...int) string {
+	result := ""
+	for _, v := range values {
+		if v < 0 {
+			v = (-v << 1) | 1
+		} else {
+			v <<= 1
+		}
+		for {
+			digit := v & 31
+			v >>= 5
+			if v > 0 {
+				digit |= 32
+			}
+			result += string(base64VLQChars[digit])
+			if v == 0 {
+				break
+			}
+		}
+	}
+	return result
+}
+
+// testSourceMap maps "function boom(){throw new Error("x")}boom();" to
+//
+//	function boom() {
+//	  throw new Error("x");
+//	}
+//	boom();
+var testSourceMap = `{"version":3,"sources":["app.ts"],"sourceRoot":"src","names":["boom"],"mappings":"` +
+	encodeVLQ(0, 0, 0, 0) + "," + encodeVLQ(9, 0, 0, 9, 0) + "," + encodeVLQ(7, 0, 1, -7) + "," + encodeVLQ(21, 0, 2, -2) + `"}`
+
+const testMinifiedScript = `function boom(){throw new Error("x")}boom();`
+
+func TestSourceMapLookup(t *testing.T) {
+	m, err := ParseSourceMap([]byte(testSourceMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pos := m.Lookup(1, 11)
+	if pos == nil || pos.Source != "src/app.ts" || pos.Line != 1 || pos.Column != 10 || pos.Name != "boom" {
+		t.Fatal(pos)
+	}
+	if pos = m.Lookup(1, 40); pos == nil || pos.Line != 4 || pos.Column != 1 {
+		t.Fatal(pos)
+	}
+	if m.Lookup(2, 1) != nil {
+		t.Fatal()
+	}
+	if _, err = ParseSourceMap([]byte(`{"version":2}`)); err != ErrInvalidSourceMap {
+		t.Fatal(err)
+	}
+}
+
+func TestSourceMapStack(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	var loaded []string
+	ctx.EnableSourceMaps(func(script string, url string) ([]byte, error) {
+		loaded = append(loaded, script+" "+url)
+		return []byte(testSourceMap), nil
+	})
+	var err *JSError
+	func() {
+		defer func() {
+			err = ToJSError(recover().(error))
+		}()
+		ctx.RunScript(testMinifiedScript+"\n//# sourceMappingURL=main.min.js.map", "main.min.js")
+	}()
+	if len(loaded) != 1 || loaded[0] != "main.min.js main.min.js.map" {
+		t.Fatal(loaded)
+	}
+	if len(err.Frames) != 2 {
+		t.Fatal(err.StackTrace)
+	}
+	if f := err.Frames[0]; f.Function != "boom" || f.Script != "src/app.ts" || f.Line != 2 || f.Column != 3 {
+		t.Fatal(err.StackTrace)
+	}
+	if f := err.Frames[1]; f.Script != "src/app.ts" || f.Line != 4 || f.Column != 1 {
+		t.Fatal(err.StackTrace)
+	}
+	if !strings.HasPrefix(err.Location, "src/app.ts:2:") {
+		t.Fatal(err.Location)
+	}
+	stack := ctx.RunScript(`try { boom() } catch (e) { e.stack }`, "caller.js")
+	defer stack.Release()
+	if !strings.Contains(stack.String(), "at boom (src/app.ts:2:3)") || !strings.Contains(stack.String(), "caller.js:1:7") {
+		t.Fatal(stack.String())
+	}
+}
+
+func TestInlineSourceMap(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	ctx.EnableSourceMaps(nil)
+	inline := "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(testSourceMap))
+	ctx.RunScript("function boom(){throw new Error(\"x\")}\n//# sourceMappingURL="+inline, "inline.js")
+	stack := ctx.RunScript(`try { boom() } catch (e) { e.stack }`, "caller.js")
+	defer stack.Release()
+	if !strings.Contains(stack.String(), "at boom (src/app.ts:2:3)") {
+		t.Fatal(stack.String())
+	}
+}
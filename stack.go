@@ -0,0 +1,132 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"errors"
+	"runtime"
+
+	"github.com/herb-go/v8go"
+)
+
+// DefaultStackLimit default max nested depth of calls from Go into javascript per isolate.
+const DefaultStackLimit = 256
+
+// ErrStackOverflow error reported when reentrancy limit set by Isolate.SetStackLimit reached,
+// or stack size of javascript exceeded.
+var ErrStackOverflow = errors.New("v8js: stack overflow")
+
+// stackOverflowMessage message of RangeError thrown by v8 when stack size exceeded.
+const stackOverflowMessage = "RangeError: Maximum call stack size exceeded"
+
+// stackOverflowHelper creates RangeError same as thrown by v8 when stack size exceeded,without helper frame.
+var stackOverflowHelper = registerHelper("stackoverflow.js", `((RangeError, captureStackTrace) => function overflow() {
+	const e = new RangeError("Maximum call stack size exceeded");
+	captureStackTrace(e, overflow);
+	return e;
+})(RangeError, Error.captureStackTrace)`)
+
+// SetStackLimit sets reentrancy limit of isolate,
+// which is max nested depth of calls from Go into javascript in contexts of isolate,
+// such as scripts called by Go callbacks which are called by javascript.
+// DefaultStackLimit used if limit is 0 or less.
+//
+// When limit exceeded,RangeError same as v8 stack overflow is thrown to javascript calling the Go callback,
+// and errors thrown while unwinding are reported to Go callers as ErrStackOverflow.
+// It is not a limit of stack size,recursion in pure javascript is limited by v8 stack size,
+// which can be set by SetStackSize or EngineOptions.StackSize,and is reported as ErrStackOverflow too.
+func (i *Isolate) SetStackLimit(limit int) {
+	i.stackLimit = limit
+}
+
+// StackLimit returns reentrancy limit of isolate.
+func (i *Isolate) StackLimit() int {
+	if i.stackLimit <= 0 {
+		return DefaultStackLimit
+	}
+	return i.stackLimit
+}
+
+// Is reports whether target is ErrStackOverflow and error is thrown while unwinding from reentrancy limit,
+// or is RangeError thrown by v8 when stack size exceeded,
+// so errors.Is(err,ErrStackOverflow) can be used to detect both of them.
+// Frames of error are top frames of overflowed stack.
+func (e *JSError) Is(target error) bool {
+	return target == ErrStackOverflow && e.overflow
+}
+
+// SetStackSize sets max stack size in bytes used by javascript of isolate,
+// which is applied to v8 by Isolate::SetStackLimit at every top level call from Go.
+// Default stack size of v8 used if size is 0 or less,which is set by EngineOptions.StackSize.
+//
+// Size should be less than stack size of threads,8MB usually,or process may crash before limit reached.
+// Isolate is locked on thread of top level call until call finished while size set.
+func (i *Isolate) SetStackSize(size int) {
+	i.stackSize = size
+}
+
+// StackSize returns max stack size set by SetStackSize.
+func (i *Isolate) StackSize() int {
+	return i.stackSize
+}
+
+// enterStack applies stack size before a top level call.
+// V8 drops stack limit when its top level locker released,
+// so isolate is locked on current thread until exitStack called.
+func (i *Isolate) enterStack() {
+	if i.stackSize <= 0 {
+		return
+	}
+	runtime.LockOSThread()
+	iso := C.V8jsIsolatePtr(nativePtr(i.Raw))
+	i.stackHold = C.V8jsIsolateLock(iso)
+	C.V8jsSetStackLimit(iso, C.size_t(i.stackSize))
+}
+
+// exitStack releases isolate locked by enterStack when top level call finished.
+func (i *Isolate) exitStack() {
+	if i.stackHold != nil {
+		C.V8jsIsolateUnlock(i.stackHold)
+		i.stackHold = nil
+		runtime.UnlockOSThread()
+	}
+}
+
+// recursive reports whether top frames of error repeat,as frames of stack overflowed by recursion,
+// so RangeError thrown by v8 is not confused with same error thrown by scripts outside recursion.
+func (e *JSError) recursive() bool {
+	seen := map[StackFrame]bool{}
+	for _, f := range e.Frames {
+		if seen[*f] {
+			return true
+		}
+		seen[*f] = true
+	}
+	return false
+}
+
+// enter starts a call from Go into javascript in isolate.
+// Panics with ErrStackOverflow if reentrancy limit reached.
+func (i *Isolate) enter() {
+	if i.depth.Add(1) > int32(i.StackLimit()) {
+		i.depth.Add(-1)
+		panic(ErrStackOverflow)
+	}
+}
+
+// exit ends a call started by enter.
+func (i *Isolate) exit() {
+	if i.depth.Add(-1) == 0 {
+		i.overflowed.Store(false)
+	}
+}
+
+// newStackOverflowError creates RangeError thrown when reentrancy limit reached.
+// Reentrancy is not checked,as it is called after limit reached.
+func (c *Context) newStackOverflowError() *v8go.Value {
+	e, err := mustAsFunction(c.helper(stackOverflowHelper).export()).Call(v8go.Undefined(c.Raw.Isolate()))
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
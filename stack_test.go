@@ -0,0 +1,93 @@
+package v8js
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStackOverflow(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	err := catchJSError(func() {
+		ctx.RunScript(`function recurse() { recurse() }
+recurse()`, "recurse.js")
+	})
+	if err == nil || err.Message != "RangeError: Maximum call stack size exceeded" || !errors.Is(err, ErrStackOverflow) || len(err.Frames) == 0 || err.Frames[0].Function != "recurse" {
+		t.Fatal(err)
+	}
+	err = catchJSError(func() {
+		ctx.RunScript(`throw new RangeError("Maximum call stack size exceeded")`, "main.js")
+	})
+	if err == nil || errors.Is(err, ErrStackOverflow) {
+		t.Fatal(err)
+	}
+}
+
+func TestStackSize(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	script := `var depth = 0
+function recurse() { depth++; recurse() }
+try { recurse() } catch (e) {}
+depth`
+	result := ctx.RunScript(script, "main.js")
+	full := result.Integer()
+	result.Release()
+	ctx.Isolate().SetStackSize(64 * 1024)
+	if ctx.Isolate().StackSize() != 64*1024 {
+		t.Fatal()
+	}
+	result = ctx.RunScript(script, "main.js")
+	limited := result.Integer()
+	result.Release()
+	if limited == 0 || limited*4 > full {
+		t.Fatal(limited, full)
+	}
+	err := catchJSError(func() {
+		ctx.RunScript(`recurse()`, "recurse.js")
+	})
+	if !errors.Is(err, ErrStackOverflow) {
+		t.Fatal(err)
+	}
+}
+
+func TestStackLimit(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	if ctx.Isolate().StackLimit() != DefaultStackLimit {
+		t.Fatal()
+	}
+	ctx.Isolate().SetStackLimit(32)
+	depth := 0
+	ctx.Global().Set("reenter", ctx.NewFunction(func(call *FunctionCallbackInfo) *Consumed {
+		depth++
+		global := call.Context().Global()
+		defer global.Release()
+		fn := global.Get("recurse")
+		defer fn.Release()
+		return fn.Call(global).Consume()
+	}).Consume())
+	result := ctx.RunScript(`function recurse() { return reenter() }
+try { recurse() } catch (e) { String(e instanceof RangeError) }`, "main.js")
+	defer result.Release()
+	if result.String() != "true" || depth != 32 {
+		t.Fatal(result.String(), depth)
+	}
+	err := catchJSError(func() {
+		ctx.RunScript(`recurse()`, "recurse.js")
+	})
+	if !errors.Is(err, ErrStackOverflow) || len(err.Frames) == 0 || err.Frames[0].Function != "recurse" {
+		t.Fatal(err)
+	}
+	ctx.Isolate().SetStackLimit(0)
+	depth = 0
+	err = catchJSError(func() {
+		ctx.RunScript(`recurse()`, "recurse.js")
+	})
+	if !errors.Is(err, ErrStackOverflow) || depth != DefaultStackLimit {
+		t.Fatal(err, depth)
+	}
+	if ctx.Isolate().depth.Load() != 0 || ctx.Isolate().overflowed.Load() {
+		t.Fatal()
+	}
+}
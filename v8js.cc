@@ -0,0 +1,1120 @@
+#include "v8js.h"
+#include "_cgo_export.h"
+
+#include <cstdlib>
+#include <cstring>
+#include <ctime>
+#include <memory>
+#include <mutex>
+#include <sstream>
+#include <unordered_map>
+#include <vector>
+
+#include "v8.h"
+#include "v8-inspector.h"
+#include "v8-profiler.h"
+#include "v8-version.h"
+
+// Mirrored structs and vendored headers are pinned to
+// github.com/herb-go/v8go v0.0.0-20251114063147-99ce5e20c7c0,which links V8 9.0.
+static_assert(V8_MAJOR_VERSION == 9 && V8_MINOR_VERSION == 0,
+              "v8js: mirrored v8go structs require V8 9.0");
+
+using namespace v8;
+
+// m_ctx,m_value and m_unboundScript mirror the structs of v8go,
+// so values created here are tracked and released by v8go.
+struct m_value;
+struct m_unboundScript;
+
+struct m_ctx {
+  Isolate* iso;
+  std::unordered_map<long, m_value*> vals;
+  std::vector<m_unboundScript*> unboundScripts;
+  Persistent<Context> ptr;
+  long nextValId;
+};
+
+struct m_value {
+  long id;
+  Isolate* iso;
+  m_ctx* ctx;
+  Persistent<Value, CopyablePersistentTraits<Value>> ptr;
+};
+
+struct m_unboundScript {
+  Persistent<UnboundScript> ptr;
+};
+
+// m_cpuProfiler and m_cpuProfile mirror CPUProfiler struct and leading field of CPUProfile struct of v8go.
+struct m_cpuProfiler {
+  CpuProfiler* ptr;
+  Isolate* iso;
+};
+
+struct m_cpuProfile {
+  CpuProfile* ptr;
+};
+
+m_value* tracked_value(m_ctx* ctx, m_value* val);
+m_unboundScript* tracked_unbound_script(m_ctx* ctx, m_unboundScript* us);
+
+#define ISOLATE_SCOPE(iso)           \
+  Locker locker(iso);                \
+  Isolate::Scope isolate_scope(iso); \
+  HandleScope handle_scope(iso);
+
+#define VALUE_SCOPE(ctx_ptr, val_ptr)        \
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr); \
+  Isolate* iso = ctx->iso;                   \
+  ISOLATE_SCOPE(iso);                        \
+  Local<Context> local_ctx = ctx->ptr.Get(iso); \
+  Context::Scope context_scope(local_ctx);   \
+  Local<Value> value = static_cast<m_value*>(val_ptr)->ptr.Get(iso);
+
+static m_value* track(m_ctx* ctx, Local<Value> value) {
+  m_value* val = new m_value;
+  val->id = 0;
+  val->iso = ctx->iso;
+  val->ctx = ctx;
+  val->ptr = Persistent<Value, CopyablePersistentTraits<Value>>(ctx->iso, value);
+  return tracked_value(ctx, val);
+}
+
+// v8js_rejection promise rejected without handler.
+struct v8js_rejection {
+  Global<Promise> promise;
+  Global<Value> reason;
+  Global<Context> context;
+  uint64_t seq;
+};
+
+struct v8js_inspector_client;
+
+// v8js_isolate native state of isolate,stored in isolate data slot 1.
+// Slot 0 is used by v8go.
+struct v8js_isolate {
+  std::vector<v8js_rejection*> rejections;
+  std::vector<Global<Context>*> tracked;
+  uint64_t seq = 0;
+  bool listening = false;
+  Global<ObjectTemplate> host_template;
+  v8js_inspector_client* inspector = nullptr;
+};
+
+static void delete_inspector(v8js_inspector_client* client);
+static void inspector_context_destroyed(v8js_inspector_client* client, Local<Context> ctx);
+
+static v8js_isolate* isolate_state(Isolate* iso) {
+  v8js_isolate* state = static_cast<v8js_isolate*>(iso->GetData(1));
+  if (state == nullptr) {
+    state = new v8js_isolate;
+    iso->SetData(1, state);
+  }
+  return state;
+}
+
+void V8jsIsolateDispose(V8jsIsolatePtr iso_ptr) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  Locker locker(iso);
+  v8js_isolate* state = static_cast<v8js_isolate*>(iso->GetData(1));
+  if (state == nullptr) {
+    return;
+  }
+  if (state->inspector != nullptr) {
+    Isolate::Scope isolate_scope(iso);
+    HandleScope handle_scope(iso);
+    delete_inspector(state->inspector);
+  }
+  for (v8js_rejection* r : state->rejections) {
+    delete r;
+  }
+  for (Global<Context>* c : state->tracked) {
+    delete c;
+  }
+  delete state;
+  iso->SetData(1, nullptr);
+}
+
+void V8jsForgetContext(V8jsContextPtr ctx_ptr) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  v8js_isolate* state = static_cast<v8js_isolate*>(iso->GetData(1));
+  if (state == nullptr) {
+    return;
+  }
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  if (state->inspector != nullptr) {
+    inspector_context_destroyed(state->inspector, local_ctx);
+  }
+  for (auto it = state->rejections.begin(); it != state->rejections.end();) {
+    if ((*it)->context.Get(iso) == local_ctx) {
+      delete *it;
+      it = state->rejections.erase(it);
+    } else {
+      ++it;
+    }
+  }
+  for (auto it = state->tracked.begin(); it != state->tracked.end(); ++it) {
+    if ((*it)->Get(iso) == local_ctx) {
+      delete *it;
+      state->tracked.erase(it);
+      break;
+    }
+  }
+}
+
+static char* copy_string(Isolate* iso, Local<Value> value) {
+  String::Utf8Value utf8(iso, value);
+  return strdup(*utf8 ? *utf8 : "");
+}
+
+V8jsValuePtr V8jsValueCopy(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  return track(ctx, value);
+}
+
+V8jsValuePtr V8jsNewArrayBuffer(V8jsContextPtr ctx_ptr, const void* data, size_t length) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  Context::Scope context_scope(local_ctx);
+  Local<ArrayBuffer> buf = ArrayBuffer::New(iso, length);
+  if (length > 0) {
+    memcpy(buf->GetBackingStore()->Data(), data, length);
+  }
+  return track(ctx, buf);
+}
+
+// V8jsArrayBufferData returns data of backing store of array buffer,and writes its byte length.
+void* V8jsArrayBufferData(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr, size_t* length) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  Local<ArrayBuffer> buf = value.As<ArrayBuffer>();
+  *length = buf->ByteLength();
+  return buf->GetBackingStore()->Data();
+}
+
+// V8jsSetSecurityToken sets security token of context,or restores default token if token is null.
+// Tokens are internalized,so contexts with same token share same token string.
+void V8jsSetSecurityToken(V8jsContextPtr ctx_ptr, const char* token) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  if (token == nullptr) {
+    local_ctx->UseDefaultSecurityToken();
+    return;
+  }
+  local_ctx->SetSecurityToken(String::NewFromUtf8(iso, token, NewStringType::kInternalized).ToLocalChecked());
+}
+
+// default_allocator of v8go,which is set when v8go initializes v8 before creating first isolate.
+extern ArrayBuffer::Allocator* default_allocator;
+
+int V8jsEngineInitialized() {
+  return default_allocator != nullptr;
+}
+
+// V8jsValueKind returns kind of value,in same order as Kind constants of kind.go.
+int V8jsValueKind(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  if (value->IsUndefined()) return 0;
+  if (value->IsNull()) return 1;
+  if (value->IsBoolean()) return 2;
+  if (value->IsNumber()) return 3;
+  if (value->IsBigInt()) return 4;
+  if (value->IsString()) return 5;
+  if (value->IsSymbol()) return 6;
+  if (value->IsProxy()) return 22;
+  if (value->IsFunction()) return 9;
+  if (value->IsArray()) return 8;
+  if (value->IsPromise()) return 10;
+  if (value->IsDate()) return 11;
+  if (value->IsRegExp()) return 12;
+  if (value->IsMap()) return 13;
+  if (value->IsSet()) return 14;
+  if (value->IsWeakMap()) return 15;
+  if (value->IsWeakSet()) return 16;
+  if (value->IsArrayBuffer()) return 17;
+  if (value->IsSharedArrayBuffer()) return 18;
+  if (value->IsTypedArray()) return 19;
+  if (value->IsDataView()) return 20;
+  if (value->IsNativeError()) return 21;
+  return 7;
+}
+
+char* V8jsValueTypeOf(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  return copy_string(iso, value->TypeOf(iso));
+}
+
+V8jsValuePtr V8jsProxyGetTarget(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  if (!value->IsProxy()) {
+    return nullptr;
+  }
+  return track(ctx, value.As<Proxy>()->GetTarget());
+}
+
+V8jsValuePtr V8jsProxyGetHandler(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  if (!value->IsProxy()) {
+    return nullptr;
+  }
+  return track(ctx, value.As<Proxy>()->GetHandler());
+}
+
+V8jsFunctionLocation V8jsFunctionGetLocation(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  V8jsFunctionLocation rtn = {nullptr, nullptr, -1, -1};
+  if (!value->IsFunction()) {
+    return rtn;
+  }
+  Local<Function> fn = value.As<Function>();
+  rtn.name = copy_string(iso, fn->GetDebugName());
+  rtn.resource = copy_string(iso, fn->GetScriptOrigin().ResourceName());
+  rtn.line = fn->GetScriptLineNumber();
+  rtn.column = fn->GetScriptColumnNumber();
+  return rtn;
+}
+
+static void on_promise_reject(PromiseRejectMessage message) {
+  Local<Promise> promise = message.GetPromise();
+  Isolate* iso = promise->GetIsolate();
+  v8js_isolate* state = isolate_state(iso);
+  switch (message.GetEvent()) {
+    case kPromiseRejectWithNoHandler: {
+      Local<Context> ctx;
+      if (!promise->GetCreationContext().ToLocal(&ctx)) {
+        return;
+      }
+      for (Global<Context>* tracked : state->tracked) {
+        if (tracked->Get(iso) == ctx) {
+          v8js_rejection* r = new v8js_rejection;
+          r->promise.Reset(iso, promise);
+          r->reason.Reset(iso, message.GetValue());
+          r->context.Reset(iso, ctx);
+          r->seq = ++state->seq;
+          state->rejections.push_back(r);
+          return;
+        }
+      }
+      return;
+    }
+    case kPromiseHandlerAddedAfterReject:
+      for (auto it = state->rejections.begin(); it != state->rejections.end(); ++it) {
+        if ((*it)->promise.Get(iso) == promise) {
+          delete *it;
+          state->rejections.erase(it);
+          return;
+        }
+      }
+      return;
+    default:
+      return;
+  }
+}
+
+void V8jsTrackRejections(V8jsContextPtr ctx_ptr) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  v8js_isolate* state = isolate_state(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  for (Global<Context>* tracked : state->tracked) {
+    if (tracked->Get(iso) == local_ctx) {
+      return;
+    }
+  }
+  state->tracked.push_back(new Global<Context>(iso, local_ctx));
+  iso->SetPromiseRejectCallback(on_promise_reject);
+}
+
+V8jsValuePtr V8jsTakeRejections(V8jsContextPtr ctx_ptr, uint64_t* mark, int all) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  Context::Scope context_scope(local_ctx);
+  v8js_isolate* state = isolate_state(iso);
+  Local<Array> result = Array::New(iso);
+  uint32_t length = 0;
+  for (auto it = state->rejections.begin(); it != state->rejections.end();) {
+    v8js_rejection* r = *it;
+    if ((all || r->seq <= *mark) && r->context.Get(iso) == local_ctx) {
+      result->Set(local_ctx, length++, r->reason.Get(iso)).Check();
+      result->Set(local_ctx, length++, r->promise.Get(iso)).Check();
+      delete r;
+      it = state->rejections.erase(it);
+    } else {
+      ++it;
+    }
+  }
+  *mark = state->seq;
+  if (length == 0) {
+    return nullptr;
+  }
+  return track(ctx, result);
+}
+
+static void on_message(Local<Message> message, Local<Value> error) {
+  Isolate* iso = message->GetIsolate();
+  HandleScope handle_scope(iso);
+  Local<Context> ctx;
+  if (!error->IsObject() || !error.As<Object>()->GetCreationContext().ToLocal(&ctx)) {
+    ctx = iso->GetEnteredOrMicrotaskContext();
+  }
+  if (ctx.IsEmpty() || ctx->GetNumberOfEmbedderDataFields() < 2) {
+    return;
+  }
+  Local<Value> ref = ctx->GetEmbedderData(1);
+  if (!ref->IsInt32()) {
+    return;
+  }
+  // error is tracked by internal context of isolate,which is always alive.
+  m_ctx* internal = static_cast<m_ctx*>(iso->GetData(0));
+  v8jsUncaughtException(ref.As<Int32>()->Value(), track(internal, error));
+}
+
+void V8jsListenUncaughtExceptions(V8jsIsolatePtr iso_ptr) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  v8js_isolate* state = isolate_state(iso);
+  if (state->listening) {
+    return;
+  }
+  state->listening = true;
+  iso->AddMessageListenerWithErrorLevel(on_message, Isolate::kMessageError);
+}
+
+// on_code_generation is called by v8 with source coerced to string,
+// for contexts disallowing code generation from strings.
+static ModifyCodeGenerationFromStringsResult on_code_generation(Local<Context> ctx, Local<Value> source, bool is_code_like) {
+  ModifyCodeGenerationFromStringsResult result;
+  // non string sources are returned by eval as is.
+  if (!source->IsString()) {
+    result.codegen_allowed = true;
+    return result;
+  }
+  if (ctx->GetNumberOfEmbedderDataFields() < 2) {
+    return result;
+  }
+  Local<Value> ref = ctx->GetEmbedderData(1);
+  if (!ref->IsInt32()) {
+    return result;
+  }
+  Isolate* iso = ctx->GetIsolate();
+  String::Utf8Value utf8(iso, source);
+  char* message = v8jsCheckCodeGeneration(ref.As<Int32>()->Value(), *utf8, utf8.length());
+  if (message == nullptr) {
+    result.codegen_allowed = true;
+    return result;
+  }
+  // EvalError thrown by v8 takes message of context.
+  ctx->SetErrorMessageForCodeGenerationFromStrings(String::NewFromUtf8(iso, message).ToLocalChecked());
+  free(message);
+  return result;
+}
+
+void V8jsDisallowCodeGeneration(V8jsContextPtr ctx_ptr) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  iso->SetModifyCodeGenerationFromStringsCallback(on_code_generation);
+  ctx->ptr.Get(iso)->AllowCodeGenerationFromStrings(false);
+}
+
+void V8jsEnqueueMicrotask(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  iso->EnqueueMicrotask(value.As<Function>());
+}
+
+static void on_interrupt(Isolate* iso, void* data) {
+  v8jsInterrupt(static_cast<int>(reinterpret_cast<intptr_t>(data)));
+}
+
+void V8jsRequestInterrupt(V8jsIsolatePtr iso_ptr, int ref) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  iso->RequestInterrupt(on_interrupt, reinterpret_cast<void*>(static_cast<intptr_t>(ref)));
+}
+
+// V8jsCurrentStackTrace captures frames of running javascript,with 1-based lines and columns.
+V8jsStackTrace V8jsCurrentStackTrace(V8jsIsolatePtr iso_ptr, int limit) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  Local<StackTrace> trace = StackTrace::CurrentStackTrace(iso, limit);
+  V8jsStackTrace rtn = {nullptr, trace->GetFrameCount()};
+  if (rtn.length == 0) {
+    return rtn;
+  }
+  rtn.frames = static_cast<V8jsFunctionLocation*>(calloc(rtn.length, sizeof(V8jsFunctionLocation)));
+  for (int i = 0; i < rtn.length; i++) {
+    Local<StackFrame> frame = trace->GetFrame(iso, i);
+    Local<String> name = frame->GetFunctionName();
+    Local<String> resource = frame->GetScriptName();
+    rtn.frames[i].name = name.IsEmpty() ? strdup("") : copy_string(iso, name);
+    rtn.frames[i].resource = resource.IsEmpty() ? strdup("") : copy_string(iso, resource);
+    rtn.frames[i].line = frame->GetLineNumber();
+    rtn.frames[i].column = frame->GetColumn();
+  }
+  return rtn;
+}
+
+// V8jsCPUProfilerStartProfiling starts profiling with samples recorded,
+// which are not recorded by profiling started by v8go.
+void V8jsCPUProfilerStartProfiling(void* profiler_ptr, const char* title) {
+  m_cpuProfiler* profiler = static_cast<m_cpuProfiler*>(profiler_ptr);
+  Isolate* iso = profiler->iso;
+  ISOLATE_SCOPE(iso);
+  profiler->ptr->StartProfiling(String::NewFromUtf8(iso, title).ToLocalChecked(), true);
+}
+
+// V8jsCPUProfileGetSamples returns node ids and timestamps of samples of profile,
+// timestamps are in microseconds since same unspecified point as start and end time.
+V8jsCPUProfileSamples V8jsCPUProfileGetSamples(void* profile_ptr) {
+  CpuProfile* profile = static_cast<m_cpuProfile*>(profile_ptr)->ptr;
+  V8jsCPUProfileSamples rtn = {nullptr, nullptr, profile->GetSamplesCount(), profile->GetStartTime(), profile->GetEndTime()};
+  if (rtn.length == 0) {
+    return rtn;
+  }
+  rtn.nodes = static_cast<unsigned*>(calloc(rtn.length, sizeof(unsigned)));
+  rtn.timestamps = static_cast<int64_t*>(calloc(rtn.length, sizeof(int64_t)));
+  for (int i = 0; i < rtn.length; i++) {
+    rtn.nodes[i] = profile->GetSample(i)->GetNodeId();
+    rtn.timestamps[i] = profile->GetSampleTimestamp(i);
+  }
+  return rtn;
+}
+
+// v8js_inspector_channel sends protocol messages of inspector session to Go session with same ref.
+class v8js_inspector_channel : public v8_inspector::V8Inspector::Channel {
+ public:
+  explicit v8js_inspector_channel(int ref) : ref(ref) {}
+
+  void sendResponse(int call_id, std::unique_ptr<v8_inspector::StringBuffer> message) override {
+    send(message->string());
+  }
+
+  void sendNotification(std::unique_ptr<v8_inspector::StringBuffer> message) override {
+    send(message->string());
+  }
+
+  void flushProtocolNotifications() override {}
+
+ private:
+  void send(v8_inspector::StringView view) {
+    const void* data = view.is8Bit() ? static_cast<const void*>(view.characters8()) : static_cast<const void*>(view.characters16());
+    v8jsInspectorSend(ref, view.is8Bit(), const_cast<void*>(data), view.length());
+  }
+
+  int ref;
+};
+
+struct v8js_inspector_session {
+  explicit v8js_inspector_session(int ref) : channel(ref) {}
+
+  v8js_inspector_channel channel;
+  std::unique_ptr<v8_inspector::V8InspectorSession> session;
+};
+
+// v8js_inspector_client inspector of isolate.
+// Each inspected context has its own context group,
+// pause loops are run by Go inspector with same group id.
+struct v8js_inspector_client : public v8_inspector::V8InspectorClient {
+  explicit v8js_inspector_client(Isolate* iso) : iso(iso) {}
+
+  void runMessageLoopOnPause(int group) override {
+    paused_group = group;
+    v8jsInspectorPause(group);
+  }
+
+  void quitMessageLoopOnPause() override {
+    v8jsInspectorQuitPause(paused_group);
+  }
+
+  void runIfWaitingForDebugger(int group) override {
+    v8jsInspectorRunIfWaiting(group);
+  }
+
+  Local<Context> ensureDefaultContextInGroup(int group) override {
+    auto it = contexts.find(group);
+    if (it == contexts.end()) {
+      return Local<Context>();
+    }
+    return it->second.Get(iso);
+  }
+
+  double currentTimeMS() override {
+    struct timespec now;
+    clock_gettime(CLOCK_REALTIME, &now);
+    return now.tv_sec * 1e3 + now.tv_nsec / 1e6;
+  }
+
+  Isolate* iso;
+  std::unique_ptr<v8_inspector::V8Inspector> inspector;
+  std::unordered_map<int, Global<Context>> contexts;
+  std::unordered_map<int, v8js_inspector_session*> sessions;
+  int paused_group = 0;
+};
+
+static void delete_inspector(v8js_inspector_client* client) {
+  for (auto& it : client->sessions) {
+    delete it.second;
+  }
+  client->sessions.clear();
+  client->contexts.clear();
+  client->inspector.reset();
+  delete client;
+}
+
+static void inspector_context_destroyed(v8js_inspector_client* client, Local<Context> ctx) {
+  for (auto it = client->contexts.begin(); it != client->contexts.end(); ++it) {
+    if (it->second.Get(client->iso) == ctx) {
+      client->contexts.erase(it);
+      client->inspector->contextDestroyed(ctx);
+      return;
+    }
+  }
+}
+
+static v8js_inspector_session* inspector_session(Isolate* iso, int ref) {
+  v8js_isolate* state = isolate_state(iso);
+  if (state->inspector == nullptr) {
+    return nullptr;
+  }
+  auto it = state->inspector->sessions.find(ref);
+  return it == state->inspector->sessions.end() ? nullptr : it->second;
+}
+
+// V8jsInspectorContextCreated reports context to inspector of isolate as default context of group,
+// inspector is created when first context reported.
+void V8jsInspectorContextCreated(V8jsContextPtr ctx_ptr, int group, const uint16_t* name, size_t length) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  Context::Scope context_scope(local_ctx);
+  v8js_isolate* state = isolate_state(iso);
+  if (state->inspector == nullptr) {
+    state->inspector = new v8js_inspector_client(iso);
+    state->inspector->inspector = v8_inspector::V8Inspector::create(iso, state->inspector);
+  }
+  static const char aux_data[] = "{\"isDefault\":true}";
+  v8_inspector::V8ContextInfo info(local_ctx, group, v8_inspector::StringView(name, length));
+  info.auxData = v8_inspector::StringView(reinterpret_cast<const uint8_t*>(aux_data), sizeof(aux_data) - 1);
+  state->inspector->contexts[group].Reset(iso, local_ctx);
+  state->inspector->inspector->contextCreated(info);
+}
+
+void V8jsInspectorContextDestroyed(V8jsContextPtr ctx_ptr) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  v8js_isolate* state = isolate_state(iso);
+  if (state->inspector != nullptr) {
+    inspector_context_destroyed(state->inspector, ctx->ptr.Get(iso));
+  }
+}
+
+void V8jsInspectorConnect(V8jsIsolatePtr iso_ptr, int group, int ref) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  v8js_isolate* state = isolate_state(iso);
+  if (state->inspector == nullptr) {
+    return;
+  }
+  v8js_inspector_session* s = new v8js_inspector_session(ref);
+  s->session = state->inspector->inspector->connect(group, &s->channel, v8_inspector::StringView());
+  state->inspector->sessions[ref] = s;
+}
+
+void V8jsInspectorDispatch(V8jsIsolatePtr iso_ptr, int ref, const uint16_t* message, size_t length) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  v8js_inspector_session* s = inspector_session(iso, ref);
+  if (s != nullptr) {
+    s->session->dispatchProtocolMessage(v8_inspector::StringView(message, length));
+  }
+}
+
+void V8jsInspectorPauseOnNextStatement(V8jsIsolatePtr iso_ptr, int ref) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  v8js_inspector_session* s = inspector_session(iso, ref);
+  if (s != nullptr) {
+    static const char reason[] = "Break on start";
+    v8_inspector::StringView view(reinterpret_cast<const uint8_t*>(reason), sizeof(reason) - 1);
+    s->session->schedulePauseOnNextStatement(view, view);
+  }
+}
+
+// V8jsInspectorDisconnect resumes javascript paused by session,then disconnects session.
+void V8jsInspectorDisconnect(V8jsIsolatePtr iso_ptr, int ref) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  v8js_inspector_session* s = inspector_session(iso, ref);
+  if (s == nullptr) {
+    return;
+  }
+  isolate_state(iso)->inspector->sessions.erase(ref);
+  s->session->resume();
+  delete s;
+}
+
+// V8jsIsolateLock locks isolate until V8jsIsolateUnlock called on same thread.
+// V8 drops thread state,such as scheduled pauses,when top level locker released,
+// so state set after locking is kept by calls made before unlocking.
+void* V8jsIsolateLock(V8jsIsolatePtr iso_ptr) {
+  return new Locker(static_cast<Isolate*>(iso_ptr));
+}
+
+void V8jsIsolateUnlock(void* locker) {
+  delete static_cast<Locker*>(locker);
+}
+
+// V8jsSetStackLimit limits stack used by javascript on current thread to size bytes below current position.
+// Isolate should be locked by V8jsIsolateLock on current thread,as v8 drops limit when top level locker released.
+void V8jsSetStackLimit(V8jsIsolatePtr iso_ptr, size_t size) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  uintptr_t here = reinterpret_cast<uintptr_t>(&iso);
+  iso->SetStackLimit(here > size ? here - size : 0);
+}
+
+static void on_inspector_interrupt(Isolate* iso, void* data) {
+  v8jsInspectorInterrupt(static_cast<int>(reinterpret_cast<intptr_t>(data)));
+}
+
+void V8jsInspectorRequestInterrupt(V8jsIsolatePtr iso_ptr, int group) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  iso->RequestInterrupt(on_inspector_interrupt, reinterpret_cast<void*>(static_cast<intptr_t>(group)));
+}
+
+// exception_error converts caught exception to error,in same format as v8go.
+static V8jsError exception_error(TryCatch& try_catch, Isolate* iso, Local<Context> ctx) {
+  HandleScope handle_scope(iso);
+  V8jsError rtn = {nullptr, nullptr, nullptr};
+  if (try_catch.HasTerminated()) {
+    rtn.msg = strdup("ExecutionTerminated: script execution has been terminated");
+    return rtn;
+  }
+  if (!try_catch.HasCaught()) {
+    rtn.msg = strdup("Error: unknown error");
+    return rtn;
+  }
+  rtn.msg = copy_string(iso, try_catch.Exception());
+  Local<Message> msg = try_catch.Message();
+  if (!msg.IsEmpty()) {
+    String::Utf8Value origin(iso, msg->GetScriptOrigin().ResourceName());
+    std::ostringstream sb;
+    sb << (*origin ? *origin : "");
+    Maybe<int> line = msg->GetLineNumber(ctx);
+    if (line.IsJust()) {
+      sb << ":" << line.ToChecked();
+    }
+    Maybe<int> start = msg->GetStartColumn(ctx);
+    if (start.IsJust()) {
+      sb << ":" << start.ToChecked() + 1;
+    }
+    rtn.location = strdup(sb.str().c_str());
+  }
+  Local<Value> stack;
+  if (try_catch.StackTrace(ctx).ToLocal(&stack)) {
+    rtn.stack = copy_string(iso, stack);
+  }
+  return rtn;
+}
+
+static ScriptOrigin script_origin(Isolate* iso, V8jsScriptOrigin origin) {
+  Local<Value> source_map_url;
+  if (origin.source_map_url != nullptr) {
+    source_map_url = String::NewFromUtf8(iso, origin.source_map_url).ToLocalChecked();
+  }
+  return ScriptOrigin(iso, String::NewFromUtf8(iso, origin.resource_name).ToLocalChecked(),
+                      origin.line_offset, origin.column_offset, false, -1, source_map_url);
+}
+
+V8jsValueResult V8jsRunScript(V8jsContextPtr ctx_ptr, const char* source, V8jsScriptOrigin origin) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  TryCatch try_catch(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  Context::Scope context_scope(local_ctx);
+  V8jsValueResult rtn = {nullptr, {nullptr, nullptr, nullptr}};
+  ScriptOrigin script_origin_ = script_origin(iso, origin);
+  Local<Script> script;
+  Local<Value> result;
+  if (!Script::Compile(local_ctx, String::NewFromUtf8(iso, source).ToLocalChecked(), &script_origin_).ToLocal(&script) ||
+      !script->Run(local_ctx).ToLocal(&result)) {
+    rtn.error = exception_error(try_catch, iso, local_ctx);
+    return rtn;
+  }
+  rtn.value = track(ctx, result);
+  return rtn;
+}
+
+V8jsScriptResult V8jsCompileScript(V8jsIsolatePtr iso_ptr, const char* source, V8jsScriptOrigin origin) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  TryCatch try_catch(iso);
+  // unbound scripts are tracked by internal context of isolate,same as v8go.
+  m_ctx* ctx = static_cast<m_ctx*>(iso->GetData(0));
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  Context::Scope context_scope(local_ctx);
+  V8jsScriptResult rtn = {nullptr, {nullptr, nullptr, nullptr}};
+  ScriptCompiler::Source script_source(String::NewFromUtf8(iso, source).ToLocalChecked(), script_origin(iso, origin));
+  Local<UnboundScript> unbound_script;
+  if (!ScriptCompiler::CompileUnboundScript(iso, &script_source).ToLocal(&unbound_script)) {
+    rtn.error = exception_error(try_catch, iso, local_ctx);
+    return rtn;
+  }
+  m_unboundScript* us = new m_unboundScript;
+  us->ptr.Reset(iso, unbound_script);
+  rtn.script = tracked_unbound_script(ctx, us);
+  return rtn;
+}
+
+V8jsValuePtr V8jsNewHostObject(V8jsContextPtr ctx_ptr) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  Context::Scope context_scope(local_ctx);
+  v8js_isolate* state = isolate_state(iso);
+  if (state->host_template.IsEmpty()) {
+    Local<ObjectTemplate> tpl = ObjectTemplate::New(iso);
+    tpl->SetInternalFieldCount(1);
+    state->host_template.Reset(iso, tpl);
+  }
+  Local<Object> obj = state->host_template.Get(iso)->NewInstance(local_ctx).ToLocalChecked();
+  return track(ctx, obj);
+}
+
+static void throw_error(Isolate* iso, const char* message) {
+  iso->ThrowException(Exception::Error(String::NewFromUtf8(iso, message).ToLocalChecked()));
+}
+
+// v8js_serializer_delegate writes host objects with data returned by Go hooks.
+class v8js_serializer_delegate : public ValueSerializer::Delegate {
+ public:
+  v8js_serializer_delegate(m_ctx* ctx, uintptr_t hooks) : ctx(ctx), hooks(hooks) {}
+
+  void ThrowDataCloneError(Local<String> message) override {
+    ctx->iso->ThrowException(Exception::Error(message));
+  }
+
+  Maybe<bool> WriteHostObject(Isolate* iso, Local<Object> object) override {
+    if (hooks == 0) {
+      return ValueSerializer::Delegate::WriteHostObject(iso, object);
+    }
+    V8jsHostData data = v8jsWriteHostObject(hooks, track(ctx, object));
+    if (data.error != nullptr) {
+      throw_error(iso, data.error);
+      free(data.error);
+      return Nothing<bool>();
+    }
+    serializer->WriteUint32(static_cast<uint32_t>(data.length));
+    serializer->WriteRawBytes(data.data, data.length);
+    free(data.data);
+    return Just(true);
+  }
+
+  ValueSerializer* serializer = nullptr;
+
+ private:
+  m_ctx* ctx;
+  uintptr_t hooks;
+};
+
+// v8js_deserializer_delegate reads host objects with Go hooks.
+class v8js_deserializer_delegate : public ValueDeserializer::Delegate {
+ public:
+  explicit v8js_deserializer_delegate(uintptr_t hooks) : hooks(hooks) {}
+
+  MaybeLocal<Object> ReadHostObject(Isolate* iso) override {
+    if (hooks == 0) {
+      return ValueDeserializer::Delegate::ReadHostObject(iso);
+    }
+    uint32_t length;
+    const void* data;
+    if (!deserializer->ReadUint32(&length) || !deserializer->ReadRawBytes(length, &data)) {
+      throw_error(iso, "invalid host object data");
+      return MaybeLocal<Object>();
+    }
+    V8jsHostObject rtn = v8jsReadHostObject(hooks, const_cast<void*>(data), length);
+    if (rtn.error != nullptr) {
+      throw_error(iso, rtn.error);
+      free(rtn.error);
+      return MaybeLocal<Object>();
+    }
+    Local<Value> value = static_cast<m_value*>(rtn.value)->ptr.Get(iso);
+    if (!value->IsObject()) {
+      throw_error(iso, "host object hook should return an object");
+      return MaybeLocal<Object>();
+    }
+    return value.As<Object>();
+  }
+
+  ValueDeserializer* deserializer = nullptr;
+
+ private:
+  uintptr_t hooks;
+};
+
+// v8js_shared holds backing store of shared or transferred array buffer.
+struct v8js_shared {
+  std::shared_ptr<BackingStore> store;
+};
+
+// transfer_buffers converts values of transfer list to array buffers.
+// Returns false with exception thrown if a value is not an array buffer.
+static bool transfer_buffers(Isolate* iso, V8jsValuePtr* transfer, int transfer_length, std::vector<Local<ArrayBuffer>>& buffers) {
+  for (int i = 0; i < transfer_length; i++) {
+    Local<Value> buf = static_cast<m_value*>(transfer[i])->ptr.Get(iso);
+    if (!buf->IsArrayBuffer()) {
+      iso->ThrowException(Exception::TypeError(String::NewFromUtf8Literal(iso, "transfer list should contain only ArrayBuffers")));
+      return false;
+    }
+    buffers.push_back(buf.As<ArrayBuffer>());
+  }
+  return true;
+}
+
+// V8jsSerialize serializes value,backing stores of transferred buffers are written to transferred,
+// which should have transfer_length slots.
+V8jsSerializeResult V8jsSerialize(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr, V8jsValuePtr* transfer, int transfer_length, V8jsShared* transferred, uintptr_t hooks) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  TryCatch try_catch(iso);
+  V8jsSerializeResult rtn = {nullptr, 0, {nullptr, nullptr, nullptr}};
+  v8js_serializer_delegate delegate(ctx, hooks);
+  ValueSerializer serializer(iso, &delegate);
+  delegate.serializer = &serializer;
+  std::vector<Local<ArrayBuffer>> buffers;
+  if (!transfer_buffers(iso, transfer, transfer_length, buffers)) {
+    rtn.error = exception_error(try_catch, iso, local_ctx);
+    return rtn;
+  }
+  for (size_t i = 0; i < buffers.size(); i++) {
+    serializer.TransferArrayBuffer(static_cast<uint32_t>(i), buffers[i]);
+  }
+  serializer.WriteHeader();
+  if (serializer.WriteValue(local_ctx, value).IsNothing()) {
+    rtn.error = exception_error(try_catch, iso, local_ctx);
+    return rtn;
+  }
+  // backing stores of transferred buffers are moved out,and buffers are detached,same as postMessage.
+  for (size_t i = 0; i < buffers.size(); i++) {
+    v8js_shared* shared = new v8js_shared;
+    shared->store = buffers[i]->GetBackingStore();
+    transferred[i] = V8jsShared{shared, shared->store->Data(), shared->store->ByteLength()};
+    if (buffers[i]->IsDetachable()) {
+      buffers[i]->Detach();
+    }
+  }
+  std::pair<uint8_t*, size_t> data = serializer.Release();
+  rtn.data = data.first;
+  rtn.length = data.second;
+  return rtn;
+}
+
+// V8jsDeserialize deserializes data,transferred backing stores are wrapped by new array buffers.
+V8jsValueResult V8jsDeserialize(V8jsContextPtr ctx_ptr, const void* data, size_t length, V8jsSharedPtr* transferred, int transferred_length, uintptr_t hooks) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  TryCatch try_catch(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  Context::Scope context_scope(local_ctx);
+  V8jsValueResult rtn = {nullptr, {nullptr, nullptr, nullptr}};
+  v8js_deserializer_delegate delegate(hooks);
+  ValueDeserializer deserializer(iso, static_cast<const uint8_t*>(data), length, &delegate);
+  delegate.deserializer = &deserializer;
+  for (int i = 0; i < transferred_length; i++) {
+    Local<ArrayBuffer> buf = ArrayBuffer::New(iso, static_cast<v8js_shared*>(transferred[i])->store);
+    deserializer.TransferArrayBuffer(static_cast<uint32_t>(i), buf);
+  }
+  Local<Value> result;
+  if (deserializer.ReadHeader(local_ctx).IsNothing() || !deserializer.ReadValue(local_ctx).ToLocal(&result)) {
+    rtn.error = exception_error(try_catch, iso, local_ctx);
+    return rtn;
+  }
+  rtn.value = track(ctx, result);
+  return rtn;
+}
+
+// v8js_shared reference to backing store of shared memory held by Go.
+static void free_shared(void* data, size_t length, void* deleter_data) {
+  free(data);
+}
+
+V8jsShared V8jsNewShared(size_t length) {
+  // calloc(0) may return NULL,always allocate at least one byte.
+  void* data = calloc(length > 0 ? length : 1, 1);
+  v8js_shared* shared = new v8js_shared;
+  shared->store = SharedArrayBuffer::NewBackingStore(data, length, free_shared, nullptr);
+  return V8jsShared{shared, data, length};
+}
+
+V8jsShared V8jsSharedFromValue(V8jsContextPtr ctx_ptr, V8jsValuePtr val_ptr) {
+  VALUE_SCOPE(ctx_ptr, val_ptr);
+  if (!value->IsSharedArrayBuffer()) {
+    return V8jsShared{nullptr, nullptr, 0};
+  }
+  v8js_shared* shared = new v8js_shared;
+  shared->store = value.As<SharedArrayBuffer>()->GetBackingStore();
+  return V8jsShared{shared, shared->store->Data(), shared->store->ByteLength()};
+}
+
+V8jsSharedPtr V8jsSharedCopy(V8jsSharedPtr ptr) {
+  v8js_shared* shared = new v8js_shared;
+  shared->store = static_cast<v8js_shared*>(ptr)->store;
+  return shared;
+}
+
+void V8jsSharedRelease(V8jsSharedPtr ptr) {
+  delete static_cast<v8js_shared*>(ptr);
+}
+
+V8jsValuePtr V8jsSharedExpose(V8jsContextPtr ctx_ptr, V8jsSharedPtr ptr) {
+  m_ctx* ctx = static_cast<m_ctx*>(ctx_ptr);
+  Isolate* iso = ctx->iso;
+  ISOLATE_SCOPE(iso);
+  Local<Context> local_ctx = ctx->ptr.Get(iso);
+  Context::Scope context_scope(local_ctx);
+  return track(ctx, SharedArrayBuffer::New(iso, static_cast<v8js_shared*>(ptr)->store));
+}
+
+// v8js_waiter isolate used by Go to wait and notify on shared memory with Atomics,
+// so Go waiters and javascript waiters of any isolate wake each other.
+struct v8js_waiter {
+  Isolate* iso;
+  Global<Context> ctx;
+  Global<Function> wait;
+  Global<Function> notify;
+};
+
+// max_idle_waiters max count of idle waiters kept for later calls,
+// waiters released when pool full are disposed.
+static const size_t max_idle_waiters = 4;
+
+static std::mutex waiters_mutex;
+static std::vector<v8js_waiter*> idle_waiters;
+static ArrayBuffer::Allocator* waiter_allocator = nullptr;
+
+static const char* waiter_script =
+    "[(b, i, v, t) => Atomics.wait(new Int32Array(b), i, v, t),"
+    " (b, i, c) => Atomics.notify(new Int32Array(b), i, c)]";
+
+// acquire_waiter takes an idle waiter,or creates a new one if all waiters busy.
+static v8js_waiter* acquire_waiter() {
+  {
+    std::lock_guard<std::mutex> lock(waiters_mutex);
+    if (!idle_waiters.empty()) {
+      v8js_waiter* w = idle_waiters.back();
+      idle_waiters.pop_back();
+      return w;
+    }
+    if (waiter_allocator == nullptr) {
+      waiter_allocator = ArrayBuffer::Allocator::NewDefaultAllocator();
+    }
+  }
+  v8js_waiter* w = new v8js_waiter;
+  Isolate::CreateParams params;
+  params.array_buffer_allocator = waiter_allocator;
+  w->iso = Isolate::New(params);
+  ISOLATE_SCOPE(w->iso);
+  Local<Context> local_ctx = Context::New(w->iso);
+  Context::Scope context_scope(local_ctx);
+  Local<Object> fns = Script::Compile(local_ctx, String::NewFromUtf8(w->iso, waiter_script).ToLocalChecked())
+                          .ToLocalChecked()
+                          ->Run(local_ctx)
+                          .ToLocalChecked()
+                          .As<Object>();
+  w->ctx.Reset(w->iso, local_ctx);
+  w->wait.Reset(w->iso, fns->Get(local_ctx, 0).ToLocalChecked().As<Function>());
+  w->notify.Reset(w->iso, fns->Get(local_ctx, 1).ToLocalChecked().As<Function>());
+  return w;
+}
+
+static void release_waiter(v8js_waiter* w) {
+  {
+    std::lock_guard<std::mutex> lock(waiters_mutex);
+    if (idle_waiters.size() < max_idle_waiters) {
+      idle_waiters.push_back(w);
+      return;
+    }
+  }
+  {
+    Locker locker(w->iso);
+    w->wait.Reset();
+    w->notify.Reset();
+    w->ctx.Reset();
+  }
+  w->iso->Dispose();
+  delete w;
+}
+
+// call_waiter calls Atomics.wait(index,arg,timeout) or Atomics.notify(index,arg) on shared memory with an idle waiter.
+// Returns 0,1 and 2 for "ok","not-equal" and "timed-out" results of wait,count of woken waiters for notify,
+// or -1 if call failed.
+static int call_waiter(V8jsSharedPtr ptr, bool wait, int index, double arg, double timeout) {
+  v8js_waiter* w = acquire_waiter();
+  int rtn = -1;
+  {
+    Isolate* iso = w->iso;
+    ISOLATE_SCOPE(iso);
+    Local<Context> local_ctx = w->ctx.Get(iso);
+    Context::Scope context_scope(local_ctx);
+    Local<Value> argv[] = {
+        SharedArrayBuffer::New(iso, static_cast<v8js_shared*>(ptr)->store),
+        Integer::New(iso, index),
+        Number::New(iso, arg),
+        Number::New(iso, timeout),
+    };
+    Local<Function> fn = wait ? w->wait.Get(iso) : w->notify.Get(iso);
+    Local<Value> result;
+    if (fn->Call(local_ctx, Undefined(iso), wait ? 4 : 3, argv).ToLocal(&result)) {
+      if (result->IsString()) {
+        String::Utf8Value s(iso, result);
+        rtn = strcmp(*s, "ok") == 0 ? 0 : strcmp(*s, "not-equal") == 0 ? 1 : 2;
+      } else {
+        rtn = result->Int32Value(local_ctx).FromMaybe(-1);
+      }
+    }
+  }
+  release_waiter(w);
+  return rtn;
+}
+
+int V8jsSharedWait(V8jsSharedPtr ptr, int index, int32_t value, double timeout) {
+  return call_waiter(ptr, true, index, value, timeout);
+}
+
+int V8jsSharedNotify(V8jsSharedPtr ptr, int index, double count) {
+  return call_waiter(ptr, false, index, count, 0);
+}
+
+void V8jsLowMemoryNotification(V8jsIsolatePtr iso_ptr) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  iso->LowMemoryNotification();
+}
+
+void V8jsMemoryPressureNotification(V8jsIsolatePtr iso_ptr, int level) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  iso->MemoryPressureNotification(static_cast<MemoryPressureLevel>(level));
+}
+
+// V8jsIdleNotification runs idle time gc work until idle seconds passed.
+// Deadline is measured by monotonic clock,same as default platform of v8go.
+int V8jsIdleNotification(V8jsIsolatePtr iso_ptr, double idle) {
+  Isolate* iso = static_cast<Isolate*>(iso_ptr);
+  ISOLATE_SCOPE(iso);
+  struct timespec now;
+  clock_gettime(CLOCK_MONOTONIC, &now);
+  double deadline = now.tv_sec + now.tv_nsec / 1e9 + idle;
+  return iso->IdleNotificationDeadline(deadline);
+}
@@ -1,18 +1,38 @@
 package v8js
 
+// #include "v8js.h"
+import "C"
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
 
 	"github.com/herb-go/v8go"
 )
 
+// NewContext creates new context with its own isolate,which will be disposed when context closed.
+// Use Isolate.NewContext to create many contexts sharing one isolate.
+// If other contexts created in isolate of context,isolate is disposed when all of them closed.
 func NewContext(opt ...v8go.ContextOption) *Context {
-	c := &Context{Raw: v8go.NewContext(opt...)}
+	startEngine()
+	raw := v8go.NewContext(opt...)
+	i := &Isolate{Raw: raw.Isolate()}
+	c := newContext(i, raw, opt)
+	i.owner = c
+	return c
+}
+
+func newContext(i *Isolate, raw *v8go.Context, opt []v8go.ContextOption) *Context {
+	c := &Context{Raw: raw, isolate: i, options: opt}
+	i.add(c)
 	c.objectTemplate = v8go.NewObjectTemplate(c.Raw.Isolate())
-	c.nullvalue = c.Wrap(v8go.Null(c.Raw.Isolate()))
+	c.nullvalue = &JsValue{raw: v8go.Null(c.Raw.Isolate()), ctx: c}
+	c.register()
 	return c
 }
 
@@ -20,7 +40,41 @@ type Context struct {
 	locker         sync.RWMutex
 	objectTemplate *v8go.ObjectTemplate
 	Raw            *v8go.Context
+	options        []v8go.ContextOption
+	isolate        *Isolate
+	securityToken  string
 	nullvalue      *JsValue
+	helpers        map[string]*JsValue
+
+	depth             int
+	rejectionMark     uint64
+	rejectionHandlers []UnhandledRejectionHandler
+	exceptionHandlers []UncaughtExceptionHandler
+
+	profiler         *v8go.CPUProfiler
+	profileName      string
+	profileStartedAt time.Time
+
+	sourceMaps      map[string]*SourceMap
+	sourceMapLoader SourceMapLoader
+
+	wasmDisabled bool
+
+	codeGenerationFilter CodeGenerationFilter
+
+	inspector *Inspector
+
+	interruptLocker sync.Mutex
+	interrupts      []func(ctx *Context)
+
+	dataLocker sync.RWMutex
+	data       map[interface{}]interface{}
+
+	setupHooks []func(ctx *Context)
+	settingUp  bool
+	policies   []func(ctx *Context)
+
+	liveValues atomic.Int64
 }
 
 func (c *Context) Close() {
@@ -29,19 +83,45 @@ func (c *Context) Close() {
 	if c.Raw == nil {
 		return
 	}
+	if c.inspector != nil {
+		c.inspector.Close()
+	}
+	if c.profiler != nil {
+		c.profiler.Dispose()
+		c.profiler = nil
+	}
 	ctx := c.Raw
 	c.Raw = nil
 	c.nullvalue = nil
+	c.helpers = nil
+	c.rejectionHandlers = nil
+	c.exceptionHandlers = nil
+	c.sourceMaps = nil
+	c.sourceMapLoader = nil
 	c.objectTemplate = nil
+	c.setupHooks = nil
+	c.policies = nil
+	c.interruptLocker.Lock()
+	c.interrupts = nil
+	c.interruptLocker.Unlock()
+	c.dataLocker.Lock()
+	c.data = nil
+	c.dataLocker.Unlock()
+	c.unregister(ctx)
 	ctx.Close()
-	ctx.Isolate().Dispose()
-	runtime.GC()
+	c.untrackAllValues()
+	c.isolate.remove(c)
+	if GoGCOnClose {
+		runtime.GC()
+	}
 }
 func (c *Context) Wrap(v *v8go.Value) *JsValue {
 	val := &JsValue{
-		raw: v,
-		ctx: c,
+		raw:   v,
+		ctx:   c,
+		owner: c.Raw,
 	}
+	c.trackValue()
 	return val
 }
 
@@ -96,10 +176,8 @@ func (c *Context) NewStringArray(values ...string) *JsValue {
 	return c.NewArray(args...)
 }
 func (c *Context) NewArray(values ...*Consumed) *JsValue {
-	a := c.RunScript("Array", "array")
-	result := a.Call(a, values...)
-	a.Release()
-	return result
+	a := c.helper(arrayHelper)
+	return a.Call(a, values...)
 }
 func (c *Context) NewObject() *JsValue {
 	obj, err := c.objectTemplate.NewInstance(c.Raw)
@@ -110,24 +188,72 @@ func (c *Context) NewObject() *JsValue {
 	return result
 }
 func (c *Context) NewArrayBuffer(data []byte) *JsValue {
-	v := c.RunScript(fmt.Sprintf("new ArrayBuffer(%d)", len(data)), "arraybuffer.js")
-	v8go.WriteToArrayBuffer(v.export(), data)
-	return v
+	var ptr unsafe.Pointer
+	if len(data) > 0 {
+		ptr = unsafe.Pointer(&data[0])
+	}
+	return c.wrapNative(C.V8jsNewArrayBuffer(c.nativeContext(), ptr, C.size_t(len(data))))
 }
 func (c *Context) NewFunctionTemplate(callback FunctionCallback) *FunctionTemplate {
 	return newFunctionTemplate(c, callback)
 }
 func (c *Context) RunScript(script string, name string) *JsValue {
-	result, err := c.Raw.RunScript(script, name)
-	if err != nil {
-		panic(err)
-	}
-	return c.Wrap(result)
+	return c.RunScriptWithOrigin(script, NewScriptOrigin(name))
 }
 func (c *Context) NullValue() *JsValue {
 	return c.nullvalue
 }
 
+var ErrHelperRegistered = errors.New("v8js: helper already registered")
+
+// helperScripts scripts of registered helpers by name.
+var helperScripts = map[string]string{}
+
+var arrayHelper = registerHelper("array.js", "Array")
+
+// registerHelper registers helper script with given name and returns the name.
+// Helpers should capture builtins they use when compiled,so they keep working after globals removed by sandbox.
+func registerHelper(name string, script string) string {
+	if _, ok := helperScripts[name]; ok {
+		panic(ErrHelperRegistered)
+	}
+	helperScripts[name] = script
+	return name
+}
+
+// RegisterHelper registers helper script used by Go code of other packages,and returns the name.
+// It should be called when package initialized,such as in package level var declarations.
+// Name should be prefixed with package path to avoid conflicts.
+// Helpers should capture builtins they use when compiled,as they are compiled before globals removed by ApplySandbox.
+func RegisterHelper(name string, script string) string {
+	return registerHelper(name, script)
+}
+
+// Helper returns the cached result of helper registered by RegisterHelper.
+// Returned value is owned by context,and should not be released.
+func (c *Context) Helper(name string) *JsValue {
+	return c.helper(name)
+}
+
+// helper returns the cached result of registered helper with given name.
+// Helpers are compiled only once per context and are not exposed to scripts.
+// Helpers only create functions,so they are compiled without reentrancy check and can be used when limit reached.
+func (c *Context) helper(name string) *JsValue {
+	if c.helpers == nil {
+		c.helpers = map[string]*JsValue{}
+	}
+	h, ok := c.helpers[name]
+	if !ok {
+		raw, err := c.Raw.RunScript(helperScripts[name], name)
+		if err != nil {
+			panic(c.convertError(err))
+		}
+		h = c.Wrap(raw)
+		c.helpers[name] = h
+	}
+	return h
+}
+
 type Reusable struct {
 	value *JsValue
 }
@@ -169,6 +295,9 @@ func (c *Consumed) Release() {
 type JsValue struct {
 	raw *v8go.Value
 	ctx *Context
+	// owner raw context value created in,values of closed or reset raw context are freed by v8go already.
+	owner    *v8go.Context
+	released bool
 }
 
 func (v *JsValue) Consume() *Consumed {
@@ -210,20 +339,33 @@ func (v *JsValue) Call(recvr *JsValue, args ...*Consumed) *JsValue {
 	for i, val := range args {
 		fnargs[i] = val.export()
 	}
+	v.ctx.enter()
+	defer v.ctx.exit()
 	val, err := fn.Call(recvr.export(), fnargs...)
+	var result *JsValue
+	if err == nil {
+		result = v.ctx.Wrap(val)
+	}
+	err = v.ctx.leave(result, err)
 	if err != nil {
 		panic(err)
 	}
 	for i := range args {
 		args[i].Release()
 	}
-	result := v.ctx.Wrap(val)
 	return result
 }
 
+// Release releases value.
+// Releasing value more than once,or values created before context closed or reset,does nothing.
 func (v *JsValue) Release() {
-	if !v.ctx.isNullValue(v) {
+	if v.ctx.isNullValue(v) || v.released {
+		return
+	}
+	v.released = true
+	if v.owner == v.ctx.Raw {
 		v.raw.Release()
+		v.ctx.untrackValue()
 	}
 }
 
@@ -486,7 +628,12 @@ type callback struct {
 func (c *callback) call(info *v8go.FunctionCallbackInfo) (output *v8go.Value) {
 	defer func() {
 		if r := recover(); r != nil {
-			errmsg, _ := v8go.NewValue(info.Context().Isolate(), r.(error).Error())
+			if errors.Is(toError(r), ErrStackOverflow) {
+				c.ctx.isolate.overflowed.Store(true)
+				output = info.Context().Isolate().ThrowException(c.ctx.newStackOverflowError())
+				return
+			}
+			errmsg, _ := v8go.NewValue(info.Context().Isolate(), toError(r).Error())
 			output = info.Context().Isolate().ThrowException(errmsg)
 		}
 	}()
@@ -496,19 +643,20 @@ func (c *callback) call(info *v8go.FunctionCallbackInfo) (output *v8go.Value) {
 		args[k] = c.ctx.Wrap(v).ConsumeReuseble().Consume()
 	}
 	this := c.ctx.Wrap(info.This().Value).ConsumeReuseble().Consume()
-	defer this.JsValue.Release()
+	defer func() {
+		this.JsValue.Release()
+		for k := range args {
+			args[k].JsValue.Release()
+		}
+	}()
 	fi := NewFunctionCallbackInfo(c.ctx, this, args...)
 	result := c.cb(fi)
-
-	for k := range args {
-		args[k].JsValue.Release()
+	if result != nil {
+		// v8go releases the returned value,hand over a new handle and release result as usual.
+		output = c.ctx.handOver(result.JsValue)
+		result.Release()
 	}
-	if result == nil {
-		return nil
-	}
-	output = result.export()
 	return output
-
 }
 
 type FunctionCallback func(info *FunctionCallbackInfo) *Consumed
@@ -565,6 +713,14 @@ func newFunctionTemplate(c *Context, callback FunctionCallback) *FunctionTemplat
 		tmpl: tmpl,
 	}
 }
+
+// toError converts recovered panic value to error.
+func toError(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
 func ExportRawValue(v *JsValue, noRelease bool) *v8go.Value {
 	return v.raw
 }
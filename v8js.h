@@ -0,0 +1,141 @@
+#ifndef V8JS_H
+#define V8JS_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+typedef void* V8jsIsolatePtr;
+typedef void* V8jsContextPtr;
+typedef void* V8jsValuePtr;
+typedef void* V8jsUnboundScriptPtr;
+typedef void* V8jsSharedPtr;
+
+typedef struct {
+  char* msg;
+  char* location;
+  char* stack;
+} V8jsError;
+
+typedef struct {
+  V8jsValuePtr value;
+  V8jsError error;
+} V8jsValueResult;
+
+typedef struct {
+  V8jsUnboundScriptPtr script;
+  V8jsError error;
+} V8jsScriptResult;
+
+typedef struct {
+  const char* resource_name;
+  int line_offset;
+  int column_offset;
+  const char* source_map_url;
+} V8jsScriptOrigin;
+
+typedef struct {
+  void* data;
+  size_t length;
+  V8jsError error;
+} V8jsSerializeResult;
+
+typedef struct {
+  void* data;
+  size_t length;
+  char* error;
+} V8jsHostData;
+
+typedef struct {
+  V8jsValuePtr value;
+  char* error;
+} V8jsHostObject;
+
+typedef struct {
+  V8jsSharedPtr shared;
+  void* data;
+  size_t length;
+} V8jsShared;
+
+typedef struct {
+  char* name;
+  char* resource;
+  int line;
+  int column;
+} V8jsFunctionLocation;
+
+typedef struct {
+  V8jsFunctionLocation* frames;
+  int length;
+} V8jsStackTrace;
+
+typedef struct {
+  unsigned* nodes;
+  int64_t* timestamps;
+  int length;
+  int64_t start;
+  int64_t end;
+} V8jsCPUProfileSamples;
+
+extern int V8jsEngineInitialized();
+extern void V8jsIsolateDispose(V8jsIsolatePtr iso);
+extern void V8jsForgetContext(V8jsContextPtr ctx);
+extern void V8jsSetSecurityToken(V8jsContextPtr ctx, const char* token);
+
+extern V8jsValuePtr V8jsValueCopy(V8jsContextPtr ctx, V8jsValuePtr val);
+extern V8jsValuePtr V8jsNewArrayBuffer(V8jsContextPtr ctx, const void* data, size_t length);
+extern void* V8jsArrayBufferData(V8jsContextPtr ctx, V8jsValuePtr val, size_t* length);
+extern int V8jsValueKind(V8jsContextPtr ctx, V8jsValuePtr val);
+extern char* V8jsValueTypeOf(V8jsContextPtr ctx, V8jsValuePtr val);
+extern V8jsValuePtr V8jsProxyGetTarget(V8jsContextPtr ctx, V8jsValuePtr val);
+extern V8jsValuePtr V8jsProxyGetHandler(V8jsContextPtr ctx, V8jsValuePtr val);
+extern void V8jsTrackRejections(V8jsContextPtr ctx);
+extern V8jsValuePtr V8jsTakeRejections(V8jsContextPtr ctx, uint64_t* mark, int all);
+extern void V8jsListenUncaughtExceptions(V8jsIsolatePtr iso);
+extern void V8jsDisallowCodeGeneration(V8jsContextPtr ctx);
+extern void V8jsEnqueueMicrotask(V8jsContextPtr ctx, V8jsValuePtr fn);
+extern void V8jsRequestInterrupt(V8jsIsolatePtr iso, int ref);
+extern V8jsStackTrace V8jsCurrentStackTrace(V8jsIsolatePtr iso, int limit);
+extern void V8jsCPUProfilerStartProfiling(void* profiler, const char* title);
+extern V8jsCPUProfileSamples V8jsCPUProfileGetSamples(void* profile);
+
+extern void V8jsInspectorContextCreated(V8jsContextPtr ctx, int group, const uint16_t* name, size_t length);
+extern void V8jsInspectorContextDestroyed(V8jsContextPtr ctx);
+extern void V8jsInspectorConnect(V8jsIsolatePtr iso, int group, int ref);
+extern void V8jsInspectorDispatch(V8jsIsolatePtr iso, int ref, const uint16_t* message, size_t length);
+extern void V8jsInspectorPauseOnNextStatement(V8jsIsolatePtr iso, int ref);
+extern void V8jsInspectorDisconnect(V8jsIsolatePtr iso, int ref);
+extern void V8jsInspectorRequestInterrupt(V8jsIsolatePtr iso, int group);
+extern void* V8jsIsolateLock(V8jsIsolatePtr iso);
+extern void V8jsIsolateUnlock(void* locker);
+extern void V8jsSetStackLimit(V8jsIsolatePtr iso, size_t size);
+
+extern V8jsValueResult V8jsRunScript(V8jsContextPtr ctx, const char* source, V8jsScriptOrigin origin);
+extern V8jsScriptResult V8jsCompileScript(V8jsIsolatePtr iso, const char* source, V8jsScriptOrigin origin);
+
+extern V8jsFunctionLocation V8jsFunctionGetLocation(V8jsContextPtr ctx, V8jsValuePtr val);
+
+extern V8jsValuePtr V8jsNewHostObject(V8jsContextPtr ctx);
+
+extern V8jsShared V8jsNewShared(size_t length);
+extern V8jsShared V8jsSharedFromValue(V8jsContextPtr ctx, V8jsValuePtr val);
+extern V8jsSharedPtr V8jsSharedCopy(V8jsSharedPtr shared);
+extern void V8jsSharedRelease(V8jsSharedPtr shared);
+extern V8jsValuePtr V8jsSharedExpose(V8jsContextPtr ctx, V8jsSharedPtr shared);
+extern int V8jsSharedWait(V8jsSharedPtr shared, int index, int32_t value, double timeout);
+extern int V8jsSharedNotify(V8jsSharedPtr shared, int index, double count);
+extern void V8jsLowMemoryNotification(V8jsIsolatePtr iso);
+extern void V8jsMemoryPressureNotification(V8jsIsolatePtr iso, int level);
+extern int V8jsIdleNotification(V8jsIsolatePtr iso, double idle);
+
+extern V8jsSerializeResult V8jsSerialize(V8jsContextPtr ctx, V8jsValuePtr val, V8jsValuePtr* transfer, int transfer_length, V8jsShared* transferred, uintptr_t hooks);
+extern V8jsValueResult V8jsDeserialize(V8jsContextPtr ctx, const void* data, size_t length, V8jsSharedPtr* transferred, int transferred_length, uintptr_t hooks);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
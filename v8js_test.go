@@ -0,0 +1,67 @@
+package v8js
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCallbackRelease(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	global := ctx.Global()
+	defer global.Release()
+	global.Set("self", ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		return info.This()
+	}).Consume())
+	global.Set("first", ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		return info.GetArg(0)
+	}).Consume())
+	global.Set("missing", ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		return info.GetArg(3)
+	}).Consume())
+	global.Set("fresh", ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		return info.Context().NewString("fresh").Consume()
+	}).Consume())
+	kept := ctx.NewString("kept")
+	defer kept.Release()
+	global.Set("reused", ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		return kept.ConsumeReuseble().Consume()
+	}).Consume())
+	script := `(() => { const o = {self}; return [o.self() === o, first(1), missing(), fresh(), reused()].join(",") })()`
+	ctx.RunScript(script, "callback.js").Release()
+	retained, live := ctx.RetainedValues(), ctx.LiveValues()
+	for i := 0; i < 10; i++ {
+		result := ctx.RunScript(script, "callback.js")
+		if result.String() != "true,1,,fresh,kept" {
+			t.Fatal(result.String())
+		}
+		result.Release()
+	}
+	if ctx.RetainedValues() != retained || ctx.LiveValues() != live {
+		t.Fatal(ctx.RetainedValues(), retained, ctx.LiveValues(), live)
+	}
+	if !ctx.NullValue().IsNull() || kept.String() != "kept" {
+		t.Fatal()
+	}
+}
+
+func TestCallbackPanicRelease(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	global := ctx.Global()
+	defer global.Release()
+	global.Set("fail", ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed {
+		panic(errors.New("failed"))
+	}).Consume())
+	live := ctx.LiveValues()
+	for i := 0; i < 100; i++ {
+		result := ctx.RunScript(`try { fail(1, 2, 3) } catch (e) { String(e) }`, "callback.js")
+		if result.String() != "failed" {
+			t.Fatal(result.String())
+		}
+		result.Release()
+	}
+	if ctx.LiveValues() != live {
+		t.Fatal(ctx.LiveValues(), live)
+	}
+}
@@ -3,18 +3,58 @@ package v8plugin
 import (
 	"github.com/herb-go/herbplugin"
 	"github.com/jarlyyn/v8js"
+	"github.com/jarlyyn/v8js/console"
+	"github.com/jarlyyn/v8js/inspector"
 )
 
 type Initializer struct {
+	// Isolate isolate hosting plugin context,plugins can share one isolate to reduce memory cost.
+	// Plugin context creates its own isolate if nil.
+	Isolate        *v8js.Isolate
+	Name           string
 	Entry          string
 	StartCommand   string
 	DisableBuiltin bool
 	Namespace      string
 	Modules        []*herbplugin.Module
+	// ConsoleLogger logger which receives console output of plugin.
+	// Console output will be printed by plugin printer if nil.
+	ConsoleLogger     console.Logger
+	DisableConsole    bool
+	DisableSourceMaps bool
+	// DisallowCodeGeneration disallows eval,new Function and string timers in plugin.
+	DisallowCodeGeneration bool
+	// CodeGenerationFilter filter of sources allowed when code generation disallowed.
+	CodeGenerationFilter v8js.CodeGenerationFilter
+	// DisableWasm disables webassembly in plugin.
+	DisableWasm bool
+	// EnableWorkers installs Worker api into plugin.
+	// Messages posted by workers are delivered only when Plugin.DispatchWorkerMessages called.
+	EnableWorkers bool
+	// MaxWorkers max count of running workers,DefaultMaxWorkers used if 0.
+	MaxWorkers int
+	// Sandbox sandbox options applied after plugin initialized,before entry loaded.
+	// Global environment is not hardened if nil.
+	Sandbox *v8js.SandboxOptions
+	// Inspector inspector server which plugin registered to as DevTools target,named by plugin name.
+	// Plugin is not inspectable if nil.
+	// Messages of DevTools are dispatched while plugin running javascript,
+	// Plugin.DispatchInspectorMessages should be called when plugin idle.
+	Inspector *inspector.Server
+	// WaitForDebugger blocks plugin loading until DevTools connected,
+	// and pauses on first statement of entry script.
+	WaitForDebugger bool
 }
 
 func (i *Initializer) MustApplyInitializer(p *Plugin) {
-	p.Runtime = v8js.NewContext()
+	if i.Isolate != nil {
+		p.Runtime = i.Isolate.NewContext()
+		p.sharedIsolate = true
+	} else {
+		p.Runtime = v8js.NewContext()
+	}
+	p.Runtime.OnUncaughtException(p.handleUncaughtException)
+	p.Runtime.OnUnhandledRejection(p.handleUnhandledRejection)
 	p.entry = i.Entry
 	p.startCommand = i.StartCommand
 	p.modules = i.Modules
@@ -23,6 +63,18 @@ func (i *Initializer) MustApplyInitializer(p *Plugin) {
 		p.namespace = DefaultNamespace
 	}
 	p.DisableBuiltin = i.DisableBuiltin
+	p.name = i.Name
+	p.consoleLogger = i.ConsoleLogger
+	p.DisableConsole = i.DisableConsole
+	p.DisableSourceMaps = i.DisableSourceMaps
+	p.DisallowCodeGeneration = i.DisallowCodeGeneration
+	p.codeGenerationFilter = i.CodeGenerationFilter
+	p.DisableWasm = i.DisableWasm
+	p.sandbox = i.Sandbox
+	p.EnableWorkers = i.EnableWorkers
+	p.MaxWorkers = i.MaxWorkers
+	p.inspectorServer = i.Inspector
+	p.waitForDebugger = i.WaitForDebugger
 }
 
 func NewInitializer() *Initializer {
@@ -3,9 +3,12 @@ package v8plugin
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/jarlyyn/v8js"
+	"github.com/jarlyyn/v8js/console"
+	"github.com/jarlyyn/v8js/inspector"
 
 	"github.com/herb-go/herbplugin"
 )
@@ -13,6 +16,9 @@ import (
 const PluginType = "js"
 const DefaultNamespace = "system"
 
+// StartCommandName script name of start command in error locations and stack traces.
+const StartCommandName = "startcommand"
+
 func New() *Plugin {
 	return &Plugin{
 		Plugin: herbplugin.New(),
@@ -29,13 +35,86 @@ type Plugin struct {
 	modules        []*herbplugin.Module
 	namespace      string
 	Builtin        map[string]*v8js.JsValue
+	name           string
+	DisableConsole bool
+	consoleLogger  console.Logger
+	// DisableSourceMaps disables source map support,stack traces will point to generated code.
+	DisableSourceMaps bool
+	// DisallowCodeGeneration disallows code generation from strings after init processes executed.
+	DisallowCodeGeneration bool
+	codeGenerationFilter   v8js.CodeGenerationFilter
+	DisableWasm            bool
+	sandbox                *v8js.SandboxOptions
+	sharedIsolate          bool
+	// EnableWorkers installs Worker api into plugin.
+	// Messages posted by workers are delivered only when Plugin.DispatchWorkerMessages called.
+	EnableWorkers bool
+	// MaxWorkers max count of running workers,DefaultMaxWorkers used if 0.
+	MaxWorkers int
+	workers    workerPool
+
+	inspectorServer *inspector.Server
+	inspectorID     string
+	waitForDebugger bool
 }
 
 func (p *Plugin) PluginType() string {
 	return PluginType
 }
-func (p *Plugin) MustInitPlugin() {
-	p.Plugin.MustInitPlugin()
+
+// PluginName returns plugin name used to tag plugin output.
+func (p *Plugin) PluginName() string {
+	return p.name
+}
+
+// loadSourceMap loads source map file referenced by plugin script.
+// Only files inside plugin location can be loaded.
+func (p *Plugin) loadSourceMap(script string, url string) ([]byte, error) {
+	location := p.PluginOptions().GetLocation()
+	if location == nil || strings.Contains(url, "://") {
+		return nil, os.ErrNotExist
+	}
+	path := location.MustCleanInsidePath(filepath.Join(filepath.Dir(script), url))
+	if path == "" {
+		return nil, os.ErrPermission
+	}
+	return os.ReadFile(path)
+}
+
+// pluginDataKey context data key of plugin owning the runtime.
+type pluginDataKey struct{}
+
+// PluginFromContext returns plugin owning given plugin or worker runtime,
+// or nil if context is not created by plugin.
+func PluginFromContext(ctx *v8js.Context) *Plugin {
+	p, _ := v8js.GetDataAs[*Plugin](ctx, pluginDataKey{})
+	return p
+}
+
+// prepareRuntime attaches plugin to and installs source maps and console into plugin or worker runtime.
+func (p *Plugin) prepareRuntime(rt *v8js.Context, tag string) {
+	rt.SetData(pluginDataKey{}, p)
+	if !p.DisableSourceMaps {
+		rt.EnableSourceMaps(p.loadSourceMap)
+	}
+	if !p.DisableConsole {
+		logger := p.consoleLogger
+		if logger == nil {
+			logger = console.NewPrinterLogger(p.PluginPrint)
+		}
+		console.Create(tag, logger).Install(rt)
+	}
+}
+
+// setupRuntime prepares plugin runtime,installs workers and builtin namespace,then runs init processes of modules.
+// It runs again when runtime reset,so globals installed by modules survive reset.
+// Workers started before reset are terminated.
+func (p *Plugin) setupRuntime(rt *v8js.Context) {
+	p.terminateWorkers()
+	p.prepareRuntime(rt, p.name)
+	if p.EnableWorkers {
+		p.installWorker(rt)
+	}
 	p.Builtin = map[string]*v8js.JsValue{}
 	var processs = make([]herbplugin.Process, 0, len(p.modules))
 	for k := range p.modules {
@@ -43,19 +122,61 @@ func (p *Plugin) MustInitPlugin() {
 			processs = append(processs, p.modules[k].InitProcess)
 		}
 	}
-	builtin := p.Runtime.NewObject()
-	for key, fn := range p.Builtin {
-		builtin.Set(key, fn.Consume())
-	}
 	herbplugin.Exec(p, processs...)
 	if !p.DisableBuiltin {
-		global := p.Runtime.Global()
+		builtin := rt.NewObject()
+		for key, fn := range p.Builtin {
+			builtin.Set(key, fn.Consume())
+		}
+		global := rt.Global()
 		global.Set(p.namespace, builtin.Consume())
+	}
+}
+
+// hardenRuntime applies code generation,webassembly and sandbox options to plugin or worker runtime.
+func (p *Plugin) hardenRuntime(rt *v8js.Context) {
+	if p.DisallowCodeGeneration {
+		rt.DisallowCodeGeneration(p.codeGenerationFilter)
+	}
+	if p.DisableWasm {
+		rt.DisableWasm()
+	}
+	if p.sandbox != nil {
+		rt.ApplySandbox(p.sandbox)
+	}
+}
+
+// InspectorID returns DevTools target id of plugin registered to inspector server,
+// or empty string if plugin is not inspectable.
+func (p *Plugin) InspectorID() string {
+	return p.inspectorID
+}
+
+// DispatchInspectorMessages dispatches messages sent by DevTools,and returns count of dispatched messages.
+// It should be called periodically by goroutine using the plugin when plugin idle,
+// messages are dispatched automatically while plugin running javascript.
+func (p *Plugin) DispatchInspectorMessages() int {
+	i := p.Runtime.Inspector()
+	if i == nil {
+		return 0
+	}
+	return i.DispatchMessages()
+}
 
+func (p *Plugin) MustInitPlugin() {
+	p.Plugin.MustInitPlugin()
+	if p.inspectorServer != nil {
+		p.inspectorID = p.inspectorServer.Register(p.name, p.Runtime.NewInspector(p.name))
 	}
+	p.Runtime.AddSetupHook(p.setupRuntime)
+	p.hardenRuntime(p.Runtime)
 }
 func (p *Plugin) MustLoadPlugin() {
 	p.Plugin.MustLoadPlugin()
+	if p.waitForDebugger && p.inspectorID != "" {
+		p.PluginPrint("Debugger listening on " + p.inspectorServer.WebSocketURL(p.inspectorID))
+		p.Runtime.Inspector().WaitForDebugger()
+	}
 	if p.entry != "" {
 		data, err := os.ReadFile(filepath.Join(p.PluginOptions().GetLocation().Path, p.entry))
 		if err != nil {
@@ -75,11 +196,12 @@ func (p *Plugin) MustBootPlugin() {
 	}
 	herbplugin.Exec(p, processs...)
 	if p.startCommand != "" {
-		p.Runtime.RunScript(p.startCommand, "")
+		p.Runtime.RunScript(p.startCommand, StartCommandName)
 	}
 }
 
 func (p *Plugin) MustClosePlugin() {
+	p.terminateWorkers()
 	var processs = make([]herbplugin.Process, 0, len(p.modules))
 	for i := len(p.modules) - 1; i >= 0; i-- {
 		if p.modules[i].CloseProcess != nil {
@@ -90,10 +212,29 @@ func (p *Plugin) MustClosePlugin() {
 	p.modules = nil
 	p.Builtin = nil
 	p.Plugin.MustClosePlugin()
+	if p.inspectorID != "" {
+		p.inspectorServer.Unregister(p.inspectorID)
+		p.inspectorID = ""
+	}
 	rt := p.Runtime
 	p.Runtime = nil
+	if p.sharedIsolate {
+		// contexts of shared isolate must not be closed concurrently with other contexts.
+		rt.Close()
+		return
+	}
 	go rt.Close()
 }
+
+// handleUncaughtException forwards uncaught script exceptions to plugin error handler.
+func (p *Plugin) handleUncaughtException(err *v8js.JSError) {
+	p.HandlePluginError(err)
+}
+
+// handleUnhandledRejection forwards unhandled promise rejections to plugin error handler.
+func (p *Plugin) handleUnhandledRejection(reason *v8js.JsValue, promise *v8js.JsValue) {
+	p.HandlePluginError(&v8js.UnhandledRejectionError{Reason: v8js.JSErrorFromValue(reason)})
+}
 func (p *Plugin) LoadJsPlugin() *Plugin {
 	return p
 }
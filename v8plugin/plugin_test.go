@@ -2,9 +2,15 @@ package v8plugin
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/herb-go/herbplugin"
+	"github.com/jarlyyn/v8js"
+	"github.com/jarlyyn/v8js/inspector"
 )
 
 var moduleinitoutput string
@@ -52,3 +58,306 @@ func TestPlugin(t *testing.T) {
 		t.Fatal(moduleinitoutput, modulebootoutput, modulecloseoutput)
 	}
 }
+
+func TestPluginErrors(t *testing.T) {
+	var errs []error
+	i := NewInitializer()
+	p := MustCreatePlugin(i)
+	p.SetPluginErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+	herbplugin.Lanuch(p, herbplugin.NewOptions())
+	defer p.MustClosePlugin()
+	fn := p.Runtime.RunScript(`(async function() { throw new Error("rejected") })`, "rejected.js")
+	fn.Call(fn).Release()
+	p.Runtime.PerformMicrotaskCheckpoint()
+	task := p.Runtime.RunScript(`(function() { throw new Error("uncaught") })`, "uncaught.js")
+	p.Runtime.EnqueueMicrotask(task.Consume())
+	p.Runtime.PerformMicrotaskCheckpoint()
+	var recovered interface{}
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		p.Runtime.RunScript(`throw new Error("returned")`, "returned.js")
+	}()
+	if len(errs) != 2 {
+		t.Fatal(errs)
+	}
+	if _, ok := errs[0].(*v8js.UnhandledRejectionError); !ok || errs[0].Error() != "unhandled promise rejection: Error: rejected" {
+		t.Fatal(errs[0])
+	}
+	if err, ok := errs[1].(*v8js.JSError); !ok || err.Message != "Error: uncaught" {
+		t.Fatal(errs[1])
+	}
+	if err := v8js.ToJSError(recovered.(error)); err == nil || err.Message != "Error: returned" {
+		t.Fatal(recovered)
+	}
+}
+
+func TestPluginConsole(t *testing.T) {
+	var output []string
+	i := NewInitializer()
+	i.Name = "testplugin"
+	i.StartCommand = `console.log("started")`
+	p := MustCreatePlugin(i)
+	p.SetPluginPrinter(func(info string) {
+		output = append(output, info)
+	})
+	herbplugin.Lanuch(p, herbplugin.NewOptions())
+	defer p.MustClosePlugin()
+	if len(output) != 1 || output[0] != "[testplugin] INFO startcommand:1:9 started" {
+		t.Fatal(output)
+	}
+}
+
+func TestPluginSourceMap(t *testing.T) {
+	var errs []error
+	i := NewInitializer()
+	i.Entry = "bundle.js"
+	p := MustCreatePlugin(i)
+	p.SetPluginErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+	opt := herbplugin.NewOptions()
+	opt.GetLocation().Path = "testscripts"
+	var err *v8js.JSError
+	func() {
+		defer func() {
+			err = v8js.ToJSError(recover().(error))
+		}()
+		herbplugin.Lanuch(p, opt)
+	}()
+	defer p.MustClosePlugin()
+	if len(errs) != 0 || err == nil {
+		t.Fatal(errs, err)
+	}
+	if len(err.Frames) != 2 || err.Frames[0].String() != "boom (src/app.ts:2:3)" {
+		t.Fatal(err.StackTrace)
+	}
+}
+
+func TestPluginCodeGeneration(t *testing.T) {
+	i := NewInitializer()
+	i.DisallowCodeGeneration = true
+	i.DisableWasm = true
+	p := MustCreatePlugin(i)
+	herbplugin.Lanuch(p, herbplugin.NewOptions())
+	defer p.MustClosePlugin()
+	result := p.Runtime.RunScript(`try { eval("1") } catch (e) { e.name + " " + typeof WebAssembly }`, "main.js")
+	defer result.Release()
+	if result.String() != "EvalError undefined" {
+		t.Fatal(result.String())
+	}
+}
+
+func TestPluginSandbox(t *testing.T) {
+	i := NewInitializer()
+	i.DisallowCodeGeneration = true
+	i.Sandbox = v8js.NewSandboxOptions()
+	i.Sandbox.Freeze = []string{DefaultNamespace, "console"}
+	p := MustCreatePlugin(i)
+	herbplugin.Lanuch(p, herbplugin.NewOptions())
+	defer p.MustClosePlugin()
+	result := p.Runtime.RunScript(`"use strict";
+try { console.log = null; "mutable" } catch (e) { typeof WebAssembly + " " + Object.isFrozen(Array.prototype) }`, "main.js")
+	defer result.Release()
+	if result.String() != "undefined true" {
+		t.Fatal(result.String())
+	}
+}
+
+func TestPluginSharedIsolate(t *testing.T) {
+	iso := v8js.NewIsolate()
+	defer iso.Dispose()
+	i := NewInitializer()
+	i.Isolate = iso
+	p1 := MustCreatePlugin(i)
+	p2 := MustCreatePlugin(i)
+	herbplugin.Lanuch(p1, herbplugin.NewOptions())
+	herbplugin.Lanuch(p2, herbplugin.NewOptions())
+	if len(iso.Contexts()) != 2 {
+		t.Fatal()
+	}
+	p1.MustClosePlugin()
+	if len(iso.Contexts()) != 1 {
+		t.Fatal()
+	}
+	p2.MustClosePlugin()
+}
+
+func dispatchUntil(p *Plugin, cond func() bool) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		p.DispatchWorkerMessages()
+		if cond() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+func TestPluginWorker(t *testing.T) {
+	i := NewInitializer()
+	i.EnableWorkers = true
+	i.MaxWorkers = 2
+	p := MustCreatePlugin(i)
+	opt := herbplugin.NewOptions()
+	opt.GetLocation().Path = "testscripts"
+	herbplugin.Lanuch(p, opt)
+	defer p.MustClosePlugin()
+	p.Runtime.RunScript(`
+globalThis.received = [];
+globalThis.errors = [];
+globalThis.worker = new Worker("worker.js");
+worker.onmessage = (e) => received.push(JSON.stringify(e.data));
+worker.onerror = (e) => errors.push(e.message);
+worker.postMessage([1, 2, 3]);
+worker.postMessage("fail");
+worker.postMessage([4]);
+`, "main.js").Release()
+	result := func(script string) string {
+		v := p.Runtime.RunScript(script, "main.js")
+		defer v.Release()
+		return v.String()
+	}
+	if !dispatchUntil(p, func() bool { return result(`received.length + errors.length`) == "4" }) {
+		t.Fatal(result(`received.join(" ")`), result(`errors.join(" ")`))
+	}
+	if result(`received.join(" ")`) != `"ready" [2,4,6] [8]` || result(`errors.join(" ")`) != "Error: worker failed" {
+		t.Fatal(result(`received.join(" ")`), result(`errors.join(" ")`))
+	}
+	p.Runtime.RunScript(`globalThis.loop = new Worker("worker_loop.js")`, "main.js").Release()
+	if result(`try { new Worker("worker.js"); "" } catch (e) { String(e) }`) != ErrTooManyWorkers.Error() {
+		t.Fatal()
+	}
+	if result(`try { new Worker("../plugin.go"); "" } catch (e) { String(e) }`) != ErrWorkerScriptOutsidePlugin.Error() {
+		t.Fatal()
+	}
+	p.Runtime.RunScript(`worker.postMessage("close")`, "main.js").Release()
+	if !dispatchUntil(p, func() bool { return len(p.workers.workers) == 1 }) {
+		t.Fatal()
+	}
+	live := p.Runtime.LiveValues()
+	p.terminateWorkers()
+	if p.Runtime.LiveValues() != live-1 {
+		t.Fatal(p.Runtime.LiveValues(), live)
+	}
+}
+
+func TestPluginWorkerDisabled(t *testing.T) {
+	p := MustCreatePlugin(NewInitializer())
+	herbplugin.Lanuch(p, herbplugin.NewOptions())
+	defer p.MustClosePlugin()
+	v := p.Runtime.RunScript(`typeof Worker`, "main.js")
+	defer v.Release()
+	if v.String() != "undefined" {
+		t.Fatal(v.String())
+	}
+}
+
+func TestPluginReset(t *testing.T) {
+	i := NewInitializer()
+	i.EnableWorkers = true
+	i.DisableWasm = true
+	inits := 0
+	i.Modules = append(i.Modules, herbplugin.CreateModule("test",
+		func(ctx context.Context, plugin herbplugin.Plugin, next func(ctx context.Context, plugin herbplugin.Plugin)) {
+			inits++
+			p := plugin.(*Plugin)
+			p.Runtime.Global().Set("moduleGlobal", p.Runtime.NewInt32(int32(inits)).Consume())
+			p.Builtin["hello"] = p.Runtime.NewString("world")
+			next(ctx, plugin)
+		}, nil, nil))
+	p := MustCreatePlugin(i)
+	opt := herbplugin.NewOptions()
+	opt.GetLocation().Path = "testscripts"
+	herbplugin.Lanuch(p, opt)
+	defer p.MustClosePlugin()
+	p.Runtime.RunScript(`globalThis.loop = new Worker("worker_loop.js")`, "main.js").Release()
+	p.Runtime.Reset()
+	if PluginFromContext(p.Runtime) != p || len(p.workers.workers) != 0 {
+		t.Fatal()
+	}
+	v := p.Runtime.RunScript(`[typeof console, typeof Worker, typeof WebAssembly, moduleGlobal, system.hello].join(",")`, "main.js")
+	defer v.Release()
+	if v.String() != "object,function,undefined,2,world" || inits != 2 {
+		t.Fatal(v.String())
+	}
+}
+
+func TestPluginFromContext(t *testing.T) {
+	p := MustCreatePlugin(NewInitializer())
+	herbplugin.Lanuch(p, herbplugin.NewOptions())
+	defer p.MustClosePlugin()
+	other := v8js.NewContext()
+	defer other.Close()
+	if PluginFromContext(p.Runtime) != p || PluginFromContext(other) != nil {
+		t.Fatal()
+	}
+}
+
+func TestPluginInspector(t *testing.T) {
+	server := inspector.NewServer("127.0.0.1:0")
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	i := NewInitializer()
+	i.Name = "inspected"
+	i.Inspector = server
+	i.WaitForDebugger = true
+	i.StartCommand = `(function() { return 1; })()`
+	p := MustCreatePlugin(i)
+	listening := make(chan *v8js.Inspector, 1)
+	p.SetPluginPrinter(func(info string) {
+		if strings.HasPrefix(info, "Debugger listening on ws://"+server.Address()+"/") {
+			listening <- p.Runtime.Inspector()
+		}
+	})
+	paused := make(chan string, 1)
+	go func() {
+		ins := <-listening
+		var s *v8js.InspectorSession
+		s = ins.Connect(func(message string) {
+			if strings.Contains(message, `"method":"Debugger.paused"`) {
+				paused <- message
+				s.Dispatch(`{"id":3,"method":"Debugger.resume"}`)
+			}
+		})
+		s.Dispatch(`{"id":1,"method":"Debugger.enable"}`)
+		s.Dispatch(`{"id":2,"method":"Runtime.runIfWaitingForDebugger"}`)
+	}()
+	herbplugin.Lanuch(p, herbplugin.NewOptions())
+	if p.InspectorID() == "" || p.DispatchInspectorMessages() != 0 {
+		t.Fatal()
+	}
+	select {
+	case message := <-paused:
+		if !strings.Contains(message, `"url":"startcommand"`) {
+			t.Fatal(message)
+		}
+	default:
+		t.Fatal()
+	}
+	resp, err := http.Get("http://" + server.Address() + "/json/list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(data), `"title":"inspected"`) {
+		t.Fatal(string(data))
+	}
+	p.MustClosePlugin()
+	resp, err = http.Get("http://" + server.Address() + "/json/list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(data) != "[]" {
+		t.Fatal(string(data))
+	}
+}
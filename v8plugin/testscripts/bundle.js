@@ -0,0 +1,2 @@
+function boom(){throw new Error("x")}boom();
+//# sourceMappingURL=bundle.js.map
@@ -0,0 +1,11 @@
+onmessage = function(e) {
+	if (e.data === "fail") {
+		throw new Error("worker failed");
+	}
+	if (e.data === "close") {
+		close();
+		return;
+	}
+	postMessage(e.data.map((x) => x * 2));
+};
+postMessage("ready");
@@ -0,0 +1,379 @@
+package v8plugin
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/jarlyyn/v8js"
+)
+
+// DefaultMaxWorkers default max count of running workers per plugin,used if workers enabled.
+const DefaultMaxWorkers = 4
+
+var ErrTooManyWorkers = errors.New("v8plugin: too many workers")
+var ErrWorkerScriptOutsidePlugin = errors.New("v8plugin: worker script outside plugin location")
+var ErrWorkerQueueFull = errors.New("v8plugin: worker message queue full")
+
+// WorkerQueueSize max count of pending messages posted to a worker.
+const WorkerQueueSize = 1024
+
+// workerBootstrap creates Worker class of plugin runtime.
+const workerBootstrap = `(function(start, post, terminate) {
+	const String = globalThis.String;
+	class Worker {
+		#id;
+		constructor(script) {
+			this.onmessage = null;
+			this.onerror = null;
+			this.#id = start(String(script), this);
+		}
+		postMessage(data) {
+			post(this.#id, data);
+		}
+		terminate() {
+			terminate(this.#id);
+		}
+	}
+	return Worker;
+})`
+
+// workerDispatcher calls event handlers of Worker objects.
+var workerDispatcher = v8js.RegisterHelper("v8plugin/worker-dispatcher.js", `((Error) => function(worker, type, payload) {
+	const handler = worker["on" + type];
+	if (typeof handler !== "function") {
+		return false;
+	}
+	if (type === "message") {
+		handler.call(worker, { data: payload, target: worker });
+	} else {
+		handler.call(worker, { message: payload, error: new Error(payload), target: worker });
+	}
+	return true;
+})(Error)`)
+
+// workerScope installs postMessage and close into worker runtime.
+const workerScope = `(function(post, close) {
+	globalThis.postMessage = (data) => post(data);
+	globalThis.close = () => close();
+	globalThis.onmessage = null;
+})`
+
+type workerEvent struct {
+	worker *worker
+	data   []byte
+	err    error
+	exit   bool
+}
+
+// worker running plugin script in its own isolate.
+type worker struct {
+	id      int
+	plugin  *Plugin
+	name    string
+	source  string
+	handle  *v8js.JsValue
+	inbox   chan []byte
+	locker  sync.Mutex
+	runtime *v8js.Context
+	closing bool
+	stopped bool
+}
+
+type workerPool struct {
+	locker  sync.Mutex
+	seq     int
+	running int
+	workers map[int]*worker
+	events  []*workerEvent
+	wg      sync.WaitGroup
+}
+
+func (p *Plugin) emitWorkerEvent(e *workerEvent) {
+	p.workers.locker.Lock()
+	defer p.workers.locker.Unlock()
+	p.workers.events = append(p.workers.events, e)
+}
+
+func (w *worker) stop() {
+	w.locker.Lock()
+	defer w.locker.Unlock()
+	if w.stopped {
+		return
+	}
+	w.stopped = true
+	close(w.inbox)
+	if w.runtime != nil {
+		w.runtime.TerminateExecution()
+	}
+}
+
+func (w *worker) isClosing() bool {
+	w.locker.Lock()
+	defer w.locker.Unlock()
+	return w.closing || w.stopped
+}
+
+// call runs fn in worker runtime and reports uncaught error to parent.
+func (w *worker) call(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			if w.isClosing() {
+				return
+			}
+			err, ok := r.(error)
+			if !ok {
+				err = errors.New("v8plugin: worker panic")
+			}
+			w.plugin.emitWorkerEvent(&workerEvent{worker: w, err: err})
+		}
+	}()
+	fn()
+}
+
+func (w *worker) run() {
+	p := w.plugin
+	defer func() {
+		p.workers.locker.Lock()
+		p.workers.running--
+		p.workers.locker.Unlock()
+		p.emitWorkerEvent(&workerEvent{worker: w, exit: true})
+		p.workers.wg.Done()
+	}()
+	defer w.stop()
+	rt := v8js.NewContext()
+	defer rt.Close()
+	w.locker.Lock()
+	if w.stopped {
+		w.locker.Unlock()
+		return
+	}
+	w.runtime = rt
+	w.locker.Unlock()
+	defer func() {
+		w.locker.Lock()
+		w.runtime = nil
+		w.locker.Unlock()
+	}()
+	w.call(func() {
+		p.prepareRuntime(rt, p.name+"/"+w.name)
+		scope := rt.RunScript(workerScope, "worker.js")
+		defer scope.Release()
+		post := rt.NewFunction(func(info *v8js.FunctionCallbackInfo) *v8js.Consumed {
+			data, err := info.GetArg(0).Serialize()
+			if err != nil {
+				panic(err)
+			}
+			p.emitWorkerEvent(&workerEvent{worker: w, data: data})
+			return nil
+		})
+		closer := rt.NewFunction(func(info *v8js.FunctionCallbackInfo) *v8js.Consumed {
+			w.locker.Lock()
+			w.closing = true
+			w.locker.Unlock()
+			return nil
+		})
+		scope.Call(rt.NullValue(), post.Consume(), closer.Consume()).Release()
+		p.hardenRuntime(rt)
+		rt.RunScript(w.source, w.name).Release()
+	})
+	for data := range w.inbox {
+		if w.isClosing() {
+			return
+		}
+		w.call(func() {
+			msg, err := rt.Deserialize(data)
+			if err != nil {
+				panic(err)
+			}
+			global := rt.Global()
+			handler := global.Get("onmessage")
+			defer handler.Release()
+			if !handler.IsFunction() {
+				msg.Release()
+				return
+			}
+			event := rt.NewObject()
+			event.Set("data", msg.Consume())
+			handler.Call(global, event.Consume()).Release()
+		})
+		if w.isClosing() {
+			return
+		}
+	}
+}
+
+func (p *Plugin) workerByID(id int) *worker {
+	p.workers.locker.Lock()
+	defer p.workers.locker.Unlock()
+	return p.workers.workers[id]
+}
+
+func (p *Plugin) startWorker(name string, obj *v8js.JsValue) int {
+	location := p.PluginOptions().GetLocation()
+	path := ""
+	if location != nil {
+		path = location.MustCleanInsidePath(name)
+	}
+	if path == "" {
+		panic(ErrWorkerScriptOutsidePlugin)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	max := p.MaxWorkers
+	if max <= 0 {
+		max = DefaultMaxWorkers
+	}
+	p.workers.locker.Lock()
+	defer p.workers.locker.Unlock()
+	if p.workers.running >= max {
+		panic(ErrTooManyWorkers)
+	}
+	if p.workers.workers == nil {
+		p.workers.workers = map[int]*worker{}
+	}
+	// arguments are released after callback returned,keep a new handle of worker object.
+	handle, err := obj.TransferTo(p.Runtime)
+	if err != nil {
+		panic(err)
+	}
+	p.workers.seq++
+	w := &worker{
+		id:     p.workers.seq,
+		plugin: p,
+		name:   name,
+		source: string(data),
+		handle: handle,
+		inbox:  make(chan []byte, WorkerQueueSize),
+	}
+	p.workers.workers[w.id] = w
+	p.workers.running++
+	p.workers.wg.Add(1)
+	go w.run()
+	return w.id
+}
+
+// installWorker installs Worker class into plugin runtime.
+// Workers run scripts inside plugin location in their own isolates,
+// messages are passed by structured clone.
+// Messages and errors posted by workers are queued until DispatchWorkerMessages called.
+func (p *Plugin) installWorker(rt *v8js.Context) {
+	bootstrap := rt.RunScript(workerBootstrap, "worker.js")
+	defer bootstrap.Release()
+	start := rt.NewFunction(func(info *v8js.FunctionCallbackInfo) *v8js.Consumed {
+		id := p.startWorker(info.GetArg(0).String(), info.GetArg(1).JsValue)
+		return info.Context().NewInt32(int32(id)).Consume()
+	})
+	post := rt.NewFunction(func(info *v8js.FunctionCallbackInfo) *v8js.Consumed {
+		w := p.workerByID(int(info.GetArg(0).Int32()))
+		if w == nil {
+			return nil
+		}
+		data, err := info.GetArg(1).Serialize()
+		if err != nil {
+			panic(err)
+		}
+		w.locker.Lock()
+		defer w.locker.Unlock()
+		if w.stopped {
+			return nil
+		}
+		select {
+		case w.inbox <- data:
+		default:
+			panic(ErrWorkerQueueFull)
+		}
+		return nil
+	})
+	terminate := rt.NewFunction(func(info *v8js.FunctionCallbackInfo) *v8js.Consumed {
+		w := p.workerByID(int(info.GetArg(0).Int32()))
+		if w != nil {
+			w.stop()
+		}
+		return nil
+	})
+	class := bootstrap.Call(rt.NullValue(), start.Consume(), post.Consume(), terminate.Consume())
+	rt.Global().Set("Worker", class.Consume())
+}
+
+// DispatchWorkerMessages delivers messages and errors posted by workers to onmessage and onerror handlers of Worker objects.
+// Events are not delivered automatically,as plugin runtime can only be used by goroutine driving plugin.
+// It must be called periodically in goroutine using plugin runtime,usually in the loop driving plugin,
+// or messages posted by workers will stay queued until plugin closed.
+// Errors without onerror handler are passed to plugin error handler.
+// Returns count of dispatched events.
+func (p *Plugin) DispatchWorkerMessages() int {
+	p.workers.locker.Lock()
+	events := p.workers.events
+	p.workers.events = nil
+	p.workers.locker.Unlock()
+	if len(events) == 0 || p.Runtime == nil {
+		return 0
+	}
+	rt := p.Runtime
+	dispatch := rt.Helper(workerDispatcher)
+	for _, e := range events {
+		if e.exit {
+			p.workers.locker.Lock()
+			delete(p.workers.workers, e.worker.id)
+			p.workers.locker.Unlock()
+			e.worker.handle.Release()
+			continue
+		}
+		func() {
+			// exceptions thrown by handlers are not returned to any caller,report them to plugin error handler.
+			defer func() {
+				if r := recover(); r != nil {
+					err, ok := r.(error)
+					if !ok {
+						err = errors.New("v8plugin: worker handler panic")
+					}
+					p.HandlePluginError(err)
+				}
+			}()
+			var eventType string
+			var payload *v8js.JsValue
+			if e.err != nil {
+				eventType = "error"
+				payload = rt.NewString(e.err.Error())
+			} else {
+				eventType = "message"
+				data, err := rt.Deserialize(e.data)
+				if err != nil {
+					panic(err)
+				}
+				payload = data
+			}
+			handled := dispatch.Call(rt.NullValue(), e.worker.handle.ConsumeReuseble().Consume(), rt.NewString(eventType).Consume(), payload.Consume())
+			defer handled.Release()
+			if e.err != nil && !handled.Boolean() {
+				p.HandlePluginError(e.err)
+			}
+		}()
+	}
+	return len(events)
+}
+
+// terminateWorkers terminates all workers and waits them exited.
+func (p *Plugin) terminateWorkers() {
+	p.workers.locker.Lock()
+	workers := make([]*worker, 0, len(p.workers.workers))
+	for _, w := range p.workers.workers {
+		workers = append(workers, w)
+	}
+	p.workers.locker.Unlock()
+	for _, w := range workers {
+		w.stop()
+	}
+	p.workers.wg.Wait()
+	p.workers.locker.Lock()
+	defer p.workers.locker.Unlock()
+	// workers not removed by dispatched exit events still hold handles of Worker objects.
+	for _, w := range p.workers.workers {
+		w.handle.Release()
+	}
+	p.workers.workers = nil
+	p.workers.events = nil
+}
@@ -0,0 +1,308 @@
+package v8js
+
+// #include "v8js.h"
+import "C"
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+)
+
+var ErrWasmDisabled = errors.New("v8js: webassembly disabled")
+var ErrWasmImportsUsed = errors.New("v8js: webassembly imports already used")
+
+// wasmHelper is null if WebAssembly global not exists when helper compiled.
+var wasmHelper = registerHelper("wasm.js", `(function(WebAssembly, Uint8Array) {
+	if (!WebAssembly) {
+		return null;
+	}
+	return {
+		compile(data) {
+			return new WebAssembly.Module(data);
+		},
+		instantiate(module, imports) {
+			return new WebAssembly.Instance(module, imports);
+		},
+		describe(module) {
+			return [WebAssembly.Module.imports(module), WebAssembly.Module.exports(module)];
+		},
+		isMemory(v) {
+			return v instanceof WebAssembly.Memory;
+		},
+		write(memory, data, offset) {
+			new Uint8Array(memory.buffer).set(new Uint8Array(data), offset);
+		}
+	};
+})(globalThis.WebAssembly, Uint8Array)`)
+
+// WasmDescriptor import or export descriptor of webassembly module.
+type WasmDescriptor struct {
+	// Module import module name,empty for exports.
+	Module string
+	Name   string
+	// Kind one of "function","table","memory" and "global".
+	Kind string
+}
+
+// WasmModule compiled webassembly module.
+type WasmModule struct {
+	ctx   *Context
+	value *JsValue
+}
+
+// Value returns WebAssembly.Module value of module.
+func (m *WasmModule) Value() *JsValue {
+	return m.value
+}
+
+// Release releases module value.
+func (m *WasmModule) Release() {
+	m.value.Release()
+}
+
+func (m *WasmModule) describe(idx int) []*WasmDescriptor {
+	list := m.ctx.wasmCall("describe", m.value.ConsumeReuseble().Consume())
+	defer list.Release()
+	items := list.GetIdx(uint32(idx))
+	defer items.Release()
+	data, err := items.Export()
+	if err != nil {
+		panic(err)
+	}
+	result := []*WasmDescriptor{}
+	for _, item := range data.([]interface{}) {
+		fields := item.(map[string]interface{})
+		d := &WasmDescriptor{
+			Name: fields["name"].(string),
+			Kind: fields["kind"].(string),
+		}
+		if module, ok := fields["module"].(string); ok {
+			d.Module = module
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
+// Imports returns import descriptors of module.
+func (m *WasmModule) Imports() []*WasmDescriptor {
+	return m.describe(0)
+}
+
+// Exports returns export descriptors of module.
+func (m *WasmModule) Exports() []*WasmDescriptor {
+	return m.describe(1)
+}
+
+// WasmImports imports used to instantiate webassembly module.
+// Imports can be used only once,as values added are consumed when imports used.
+type WasmImports struct {
+	functions map[string]map[string]FunctionCallback
+	values    map[string]map[string]*JsValue
+	used      bool
+}
+
+// NewWasmImports creates new empty imports.
+func NewWasmImports() *WasmImports {
+	return &WasmImports{
+		functions: map[string]map[string]FunctionCallback{},
+		values:    map[string]map[string]*JsValue{},
+	}
+}
+
+// Func adds Go callback as imported function with given module and name.
+func (i *WasmImports) Func(module string, name string, callback FunctionCallback) *WasmImports {
+	if i.functions[module] == nil {
+		i.functions[module] = map[string]FunctionCallback{}
+	}
+	i.functions[module][name] = callback
+	return i
+}
+
+// Value adds javascript value as import with given module and name,such as memory,table,global or javascript function.
+// Value will be consumed when imports used.
+// Panics with ErrWasmImportsUsed if imports already used.
+func (i *WasmImports) Value(module string, name string, value *Consumed) *WasmImports {
+	if i.used {
+		panic(ErrWasmImportsUsed)
+	}
+	if i.values[module] == nil {
+		i.values[module] = map[string]*JsValue{}
+	}
+	i.values[module][name] = value.JsValue
+	return i
+}
+
+func (i *WasmImports) convert(c *Context) *JsValue {
+	if i.used {
+		panic(ErrWasmImportsUsed)
+	}
+	i.used = true
+	obj := c.NewObject()
+	modules := map[string]*JsValue{}
+	module := func(name string) *JsValue {
+		m := modules[name]
+		if m == nil {
+			m = c.NewObject()
+			modules[name] = m
+		}
+		return m
+	}
+	for name, functions := range i.functions {
+		m := module(name)
+		for key, callback := range functions {
+			m.Set(key, c.NewFunction(callback).Consume())
+		}
+	}
+	for name, values := range i.values {
+		m := module(name)
+		for key, value := range values {
+			m.Set(key, value.Consume())
+		}
+	}
+	for name, m := range modules {
+		obj.Set(name, m.Consume())
+	}
+	return obj
+}
+
+// WasmInstance instantiated webassembly module.
+type WasmInstance struct {
+	ctx     *Context
+	value   *JsValue
+	exports *JsValue
+}
+
+// Value returns WebAssembly.Instance value of instance.
+func (i *WasmInstance) Value() *JsValue {
+	return i.value
+}
+
+// Exports returns exports object of instance.
+func (i *WasmInstance) Exports() *JsValue {
+	return i.exports
+}
+
+// Export returns export of instance with given name.
+func (i *WasmInstance) Export(name string) *JsValue {
+	return i.exports.Get(name)
+}
+
+// Call calls exported function with given name.
+func (i *WasmInstance) Call(name string, args ...*Consumed) *JsValue {
+	fn := i.exports.Get(name)
+	defer fn.Release()
+	return fn.Call(i.exports, args...)
+}
+
+// Memory returns exported memory with given name.
+// Return nil if export is not a memory.
+func (i *WasmInstance) Memory(name string) *WasmMemory {
+	v := i.exports.Get(name)
+	ok := i.ctx.wasmCall("isMemory", v.ConsumeReuseble().Consume())
+	defer ok.Release()
+	if !ok.Boolean() {
+		v.Release()
+		return nil
+	}
+	return &WasmMemory{ctx: i.ctx, value: v}
+}
+
+// Release releases instance values.
+func (i *WasmInstance) Release() {
+	i.exports.Release()
+	i.value.Release()
+}
+
+// WasmMemory linear memory of webassembly instance.
+type WasmMemory struct {
+	ctx   *Context
+	value *JsValue
+}
+
+// Value returns WebAssembly.Memory value of memory.
+func (m *WasmMemory) Value() *JsValue {
+	return m.value
+}
+
+// Bytes returns live view of memory content,writes are visible to webassembly and javascript.
+// Growing memory by memory.grow or WebAssembly.Memory.grow invalidates the view,
+// as buffer of memory is detached and may be moved,Bytes should be called again after memory grown.
+// View must not be used after memory released.
+func (m *WasmMemory) Bytes() []byte {
+	buffer := m.value.Get("buffer")
+	defer buffer.Release()
+	var length C.size_t
+	data := C.V8jsArrayBufferData(m.ctx.nativeContext(), buffer.native(), &length)
+	runtime.KeepAlive(buffer)
+	if length == 0 {
+		return []byte{}
+	}
+	return unsafe.Slice((*byte)(data), int(length))
+}
+
+// Size returns memory size in bytes.
+func (m *WasmMemory) Size() int {
+	buffer := m.value.Get("buffer")
+	defer buffer.Release()
+	length := buffer.Get("byteLength")
+	defer length.Release()
+	return int(length.Integer())
+}
+
+// Write writes data to memory at given offset.
+func (m *WasmMemory) Write(offset int, data []byte) {
+	m.ctx.wasmCall("write", m.value.ConsumeReuseble().Consume(), m.ctx.NewArrayBuffer(data).Consume(), m.ctx.NewInt32(int32(offset)).Consume()).Release()
+}
+
+// Release releases memory value.
+func (m *WasmMemory) Release() {
+	m.value.Release()
+}
+
+func (c *Context) wasmCall(method string, args ...*Consumed) *JsValue {
+	h := c.helper(wasmHelper)
+	if c.wasmDisabled || h.IsNull() {
+		for _, arg := range args {
+			arg.Release()
+		}
+		panic(ErrWasmDisabled)
+	}
+	return h.MethodCall(method, args...)
+}
+
+// CompileWasm compiles webassembly module from binary data.
+func (c *Context) CompileWasm(data []byte) *WasmModule {
+	return &WasmModule{
+		ctx:   c,
+		value: c.wasmCall("compile", c.NewArrayBuffer(data).Consume()),
+	}
+}
+
+// InstantiateWasm instantiates compiled webassembly module with given imports.
+// Imports can be nil if module has no imports.
+// Panics with ErrWasmImportsUsed if imports already used to instantiate a module.
+func (c *Context) InstantiateWasm(module *WasmModule, imports *WasmImports) *WasmInstance {
+	if imports == nil {
+		imports = NewWasmImports()
+	}
+	instance := c.wasmCall("instantiate", module.value.ConsumeReuseble().Consume(), imports.convert(c).Consume())
+	return &WasmInstance{
+		ctx:     c,
+		value:   instance,
+		exports: instance.Get("exports"),
+	}
+}
+
+// DisableWasm disables webassembly in context.
+// WebAssembly global is removed and CompileWasm panics with ErrWasmDisabled.
+// CompileWasm also panics with ErrWasmDisabled if WebAssembly global removed before first used.
+func (c *Context) DisableWasm() {
+	c.keepPolicy(func(ctx *Context) {
+		ctx.DisableWasm()
+	})
+	c.wasmDisabled = true
+	g := c.Global()
+	defer g.Release()
+	g.Delete("WebAssembly")
+}
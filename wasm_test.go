@@ -0,0 +1,131 @@
+package v8js
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testWasm module imports env.log(i32),exports add(i32,i32) i32,callLog(i32) and one page memory.
+var testWasm = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x0b, 0x02, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f, 0x60, 0x01, 0x7f, 0x00,
+	0x02, 0x0b, 0x01, 0x03, 0x65, 0x6e, 0x76, 0x03, 0x6c, 0x6f, 0x67, 0x00, 0x01,
+	0x03, 0x03, 0x02, 0x00, 0x01,
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	0x07, 0x1a, 0x03,
+	0x03, 0x61, 0x64, 0x64, 0x00, 0x01,
+	0x07, 0x63, 0x61, 0x6c, 0x6c, 0x4c, 0x6f, 0x67, 0x00, 0x02,
+	0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00,
+	0x0a, 0x10, 0x02,
+	0x07, 0x00, 0x20, 0x00, 0x20, 0x01, 0x6a, 0x0b,
+	0x06, 0x00, 0x20, 0x00, 0x10, 0x00, 0x0b,
+}
+
+func TestWasm(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	module := ctx.CompileWasm(testWasm)
+	defer module.Release()
+	imports := module.Imports()
+	if len(imports) != 1 || *imports[0] != (WasmDescriptor{Module: "env", Name: "log", Kind: "function"}) {
+		t.Fatal(imports)
+	}
+	exports := module.Exports()
+	if len(exports) != 3 || exports[2].Name != "memory" || exports[2].Kind != "memory" {
+		t.Fatal(exports)
+	}
+	var logged []int32
+	instance := ctx.InstantiateWasm(module, NewWasmImports().Func("env", "log", func(info *FunctionCallbackInfo) *Consumed {
+		logged = append(logged, info.GetArg(0).Int32())
+		return nil
+	}))
+	defer instance.Release()
+	sum := instance.Call("add", ctx.NewInt32(1).Consume(), ctx.NewInt32(2).Consume())
+	defer sum.Release()
+	if sum.Int32() != 3 {
+		t.Fatal(sum.Int32())
+	}
+	instance.Call("callLog", ctx.NewInt32(42).Consume()).Release()
+	if len(logged) != 1 || logged[0] != 42 {
+		t.Fatal(logged)
+	}
+	memory := instance.Memory("memory")
+	defer memory.Release()
+	if memory.Size() != 65536 || instance.Memory("add") != nil {
+		t.Fatal(memory.Size())
+	}
+	memory.Write(10, []byte("hello"))
+	data := memory.Bytes()
+	if len(data) != 65536 || !bytes.Equal(data[10:15], []byte("hello")) {
+		t.Fatal(data[:16])
+	}
+	data[20] = 42
+	memory.Write(21, []byte{43})
+	view := ctx.RunScript(`(memory) => new Uint8Array(memory.buffer, 20, 2)`, "main.js")
+	defer view.Release()
+	bytesView := view.Call(ctx.NullValue(), memory.Value().ConsumeReuseble().Consume())
+	defer bytesView.Release()
+	if bytesView.GetIdx(0).Int32() != 42 || data[21] != 43 {
+		t.Fatal(bytesView.String(), data[21])
+	}
+}
+
+func TestWasmInvalid(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	if catchJSError(func() { ctx.CompileWasm([]byte("not wasm")) }) == nil {
+		t.Fatal()
+	}
+}
+
+func TestDisableWasm(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	ctx.DisableWasm()
+	result := ctx.RunScript(`typeof WebAssembly`, "main.js")
+	defer result.Release()
+	if result.String() != "undefined" {
+		t.Fatal(result.String())
+	}
+	defer func() {
+		if r := recover(); r != ErrWasmDisabled {
+			t.Fatal(r)
+		}
+	}()
+	ctx.CompileWasm(testWasm)
+}
+
+func TestWasmRemoved(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	live := ctx.LiveValues()
+	ctx.DisableWasm()
+	if ctx.LiveValues() != live {
+		t.Fatal(ctx.LiveValues(), live)
+	}
+	removed := NewContext()
+	defer removed.Close()
+	removed.RunScript(`delete globalThis.WebAssembly`, "main.js").Release()
+	defer func() {
+		if r := recover(); r != ErrWasmDisabled {
+			t.Fatal(r)
+		}
+	}()
+	removed.CompileWasm(testWasm)
+}
+
+func TestWasmImportsUsed(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	module := ctx.CompileWasm(testWasm)
+	defer module.Release()
+	log := ctx.NewFunction(func(info *FunctionCallbackInfo) *Consumed { return nil })
+	imports := NewWasmImports().Value("env", "log", log.Consume())
+	ctx.InstantiateWasm(module, imports).Release()
+	defer func() {
+		if r := recover(); r != ErrWasmImportsUsed {
+			t.Fatal(r)
+		}
+	}()
+	ctx.InstantiateWasm(module, imports)
+}